@@ -0,0 +1,214 @@
+package splash
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+
+	"github.com/alecthomas/chroma/v2"
+)
+
+// Base16Scheme is a Base16 or Base24 color scheme, with 16 or 24 colors
+// named base00 to base0F, or base00 to base17.
+// See https://github.com/tinted-theming/home for the scheme format.
+type Base16Scheme struct {
+	Name    string            // the name of the scheme, like "Tomorrow Night"
+	Author  string            // the author of the scheme, if given
+	Variant string            // "dark" or "light", if given
+	Palette map[string]string // the colors, like "base00" -> "#1d1f21"
+}
+
+// base16Slots are the colors that all Base16 and Base24 schemes have
+var base16Slots = []string{
+	"base00", "base01", "base02", "base03", "base04", "base05", "base06", "base07",
+	"base08", "base09", "base0A", "base0B", "base0C", "base0D", "base0E", "base0F",
+}
+
+// base24Slots are the additional colors of a Base24 scheme
+var base24Slots = []string{
+	"base10", "base11", "base12", "base13", "base14", "base15", "base16", "base17",
+}
+
+// base16Entries maps chroma token types to the Base16 slots, following the
+// Base16 styling guidelines. The first slot is the foreground color, and
+// "bg:" slots are background colors.
+var base16Entries = map[chroma.TokenType]string{
+	chroma.Background:          "bg:base00 base05",
+	chroma.LineHighlight:       "bg:base01",
+	chroma.LineNumbers:         "base04",
+	chroma.LineNumbersTable:    "base04",
+	chroma.Error:               "base08",
+	chroma.Comment:             "italic base03",
+	chroma.CommentPreproc:      "base0F",
+	chroma.Keyword:             "base0E",
+	chroma.KeywordConstant:     "base09",
+	chroma.KeywordType:         "base0A",
+	chroma.Name:                "base05",
+	chroma.NameAttribute:       "base0D",
+	chroma.NameBuiltin:         "base0C",
+	chroma.NameClass:           "base0A",
+	chroma.NameConstant:        "base09",
+	chroma.NameDecorator:       "base0C",
+	chroma.NameException:       "base08",
+	chroma.NameFunction:        "base0D",
+	chroma.NameNamespace:       "base0A",
+	chroma.NameTag:             "base08",
+	chroma.NameVariable:        "base08",
+	chroma.Literal:             "base09",
+	chroma.LiteralString:       "base0B",
+	chroma.LiteralStringEscape: "base0C",
+	chroma.LiteralStringRegex:  "base0C",
+	chroma.LiteralNumber:       "base09",
+	chroma.Operator:            "base05",
+	chroma.Punctuation:         "base05",
+	chroma.GenericDeleted:      "base08",
+	chroma.GenericError:        "base08",
+	chroma.GenericInserted:     "base0B",
+	chroma.GenericHeading:      "bold base0D",
+	chroma.GenericSubheading:   "bold base0C",
+	chroma.GenericPrompt:       "base04",
+	chroma.GenericEmph:         "italic",
+	chroma.GenericStrong:       "bold",
+}
+
+// base24Entries are the token types that use the brighter colors of a Base24 scheme
+var base24Entries = map[chroma.TokenType]string{
+	chroma.NameBuiltin:    "base15",
+	chroma.GenericHeading: "bold base16",
+}
+
+// ParseBase16 reads a Base16 or Base24 scheme in YAML. Both the older format,
+// with "scheme" and the colors at the top level, and the newer format, with
+// "name" and the colors under "palette", are supported.
+func ParseBase16(r io.Reader) (*Base16Scheme, error) {
+	scheme := &Base16Scheme{Palette: make(map[string]string)}
+	scanner := bufio.NewScanner(r)
+	lineNumber := 0
+	for scanner.Scan() {
+		lineNumber++
+		line := scanner.Text()
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" || strings.HasPrefix(trimmed, "#") || trimmed == "---" {
+			continue
+		}
+		key, value, found := strings.Cut(trimmed, ":")
+		if !found {
+			return nil, fmt.Errorf("line %d: expected \"key: value\", got %q", lineNumber, trimmed)
+		}
+		key, value = strings.TrimSpace(key), yamlScalar(value)
+		switch {
+		case strings.HasPrefix(strings.ToLower(key), "base"):
+			color := chroma.ParseColour("#" + strings.TrimPrefix(value, "#"))
+			if len(strings.TrimPrefix(value, "#")) != 6 || !color.IsSet() {
+				return nil, fmt.Errorf("line %d: invalid color %q for %s", lineNumber, value, key)
+			}
+			scheme.Palette[normalizeSlot(key)] = color.String()
+		case line != trimmed:
+			// Indented keys other than colors, like under "palette", are not used
+		case key == "scheme" || key == "name":
+			scheme.Name = value
+		case key == "author":
+			scheme.Author = value
+		case key == "variant":
+			scheme.Variant = value
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return scheme, nil
+}
+
+// yamlScalar returns the given YAML value without quotes and trailing comments
+func yamlScalar(value string) string {
+	value = strings.TrimSpace(value)
+	if len(value) >= 2 && (value[0] == '"' || value[0] == '\'') {
+		if end := strings.IndexByte(value[1:], value[0]); end >= 0 {
+			return value[1 : end+1]
+		}
+	}
+	if i := strings.Index(value, " #"); i >= 0 {
+		value = value[:i]
+	}
+	return strings.TrimSpace(value)
+}
+
+// normalizeSlot returns the slot name as it is written in the Base16
+// specification, like "base0A" for "base0a"
+func normalizeSlot(key string) string {
+	return "base" + strings.ToUpper(key[len("base"):])
+}
+
+// IsBase24 returns true if the scheme has the additional Base24 colors
+func (s *Base16Scheme) IsBase24() bool {
+	for _, slot := range base24Slots {
+		if _, ok := s.Palette[slot]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// Style converts the scheme to a chroma style, with the same name as the scheme
+func (s *Base16Scheme) Style() (*chroma.Style, error) {
+	slots := base16Slots
+	if s.IsBase24() {
+		slots = append(slots[:len(slots):len(slots)], base24Slots...)
+	}
+	for _, slot := range slots {
+		if _, ok := s.Palette[slot]; !ok {
+			return nil, &StyleError{Style: s.Name, Err: fmt.Errorf("the scheme has no color for %s", slot)}
+		}
+	}
+	var entries []styleEntry
+	for tokenType, entry := range base16Entries {
+		if s.IsBase24() && base24Entries[tokenType] != "" {
+			entry = base24Entries[tokenType]
+		}
+		fields := strings.Fields(entry)
+		for i, field := range fields {
+			prefix, slot, _ := strings.Cut(field, "base")
+			if slot != "" {
+				fields[i] = prefix + s.Palette["base"+slot]
+			}
+		}
+		entries = append(entries, styleEntry{Type: tokenType.String(), Style: strings.Join(fields, " ")})
+	}
+	return buildStyle("", s.Name, entries)
+}
+
+// RegisterBase16 reads a Base16 or Base24 scheme in YAML, converts it to a
+// chroma style and registers it
+func RegisterBase16(r io.Reader) (*chroma.Style, error) {
+	scheme, err := ParseBase16(r)
+	if err != nil {
+		return nil, &StyleError{Err: err}
+	}
+	style, err := scheme.Style()
+	if err != nil {
+		return nil, err
+	}
+	registerStyle(style)
+	return style, nil
+}
+
+// parseBase16File reads a Base16 or Base24 scheme from a file and converts it
+// to a chroma style. If the scheme has no name, the filename is used.
+func parseBase16File(f io.Reader, path string) (*chroma.Style, error) {
+	scheme, err := ParseBase16(f)
+	if err != nil {
+		return nil, &StyleError{Source: path, Err: err}
+	}
+	if scheme.Name == "" {
+		scheme.Name = strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+	}
+	style, err := scheme.Style()
+	var styleErr *StyleError
+	if errors.As(err, &styleErr) {
+		styleErr.Source = path
+	}
+	return style, err
+}
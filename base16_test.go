@@ -0,0 +1,106 @@
+package splash
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/alecthomas/chroma/v2"
+)
+
+const tomorrowNight = `scheme: "Tomorrow Night"
+author: "Chris Kempson (http://chriskempson.com)"
+base00: "1d1f21"
+base01: "282a2e"
+base02: "373b41"
+base03: "969896"
+base04: "b4b7b4"
+base05: "c5c8c6"
+base06: "e0e0e0"
+base07: "ffffff"
+base08: "cc6666"
+base09: "de935f"
+base0A: "f0c674"
+base0B: "b5bd68"
+base0C: "8abeb7"
+base0D: "81a2be"
+base0E: "b294bb"
+base0F: "a3685a"
+`
+
+func TestParseBase16(t *testing.T) {
+	scheme, err := ParseBase16(strings.NewReader(tomorrowNight))
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertEqual(t, "Tomorrow Night", scheme.Name, "")
+	assertEqual(t, 16, len(scheme.Palette), "")
+	assertEqual(t, "#f0c674", scheme.Palette["base0A"], "")
+	assertEqual(t, false, scheme.IsBase24(), "")
+
+	style, err := scheme.Style()
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertEqual(t, "Tomorrow Night", style.Name, "")
+	assertEqual(t, "#b294bb", style.Get(chroma.Keyword).Colour.String(), "expected keywords to use base0E")
+	assertEqual(t, "#1d1f21", style.Get(chroma.Background).Background.String(), "expected the background to use base00")
+	assertEqual(t, "#b5bd68", style.Get(chroma.LiteralString).Colour.String(), "expected strings to use base0B")
+}
+
+func TestParseBase24(t *testing.T) {
+	input := `system: "base24"
+name: "Test Scheme"
+variant: "dark"
+palette:
+`
+	for i, slot := range append(base16Slots, base24Slots...) {
+		input += fmt.Sprintf("  %s: \"#0000%02x\" # a comment\n", slot, i)
+	}
+	scheme, err := ParseBase16(strings.NewReader(input))
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertEqual(t, "Test Scheme", scheme.Name, "")
+	assertEqual(t, "dark", scheme.Variant, "")
+	assertEqual(t, true, scheme.IsBase24(), "")
+	style, err := scheme.Style()
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertEqual(t, scheme.Palette["base15"], style.Get(chroma.NameBuiltin).Colour.String(), "expected builtins to use base15")
+}
+
+func TestBase16Errors(t *testing.T) {
+	if _, err := ParseBase16(strings.NewReader("base00: \"12\"\n")); err == nil || !strings.Contains(err.Error(), "line 1") {
+		t.Errorf("expected an error with the line number for an invalid color, got %v", err)
+	}
+
+	missing := strings.Replace(tomorrowNight, "base0C: \"8abeb7\"\n", "", 1)
+	scheme, err := ParseBase16(strings.NewReader(missing))
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = scheme.Style()
+	var styleErr *StyleError
+	if !errors.As(err, &styleErr) || !strings.Contains(err.Error(), "base0C") {
+		t.Errorf("expected a StyleError for the missing base0C color, got %v", err)
+	}
+}
+
+func TestRegisterBase16File(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "test-tomorrow-night.yaml")
+	if err := os.WriteFile(path, []byte(tomorrowNight), 0644); err != nil {
+		t.Fatal(err)
+	}
+	style, err := RegisterStyleFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if getStyle("test-tomorrow-night") != style {
+		t.Error("expected the Base16 style to be found by its filename")
+	}
+}
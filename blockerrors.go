@@ -0,0 +1,124 @@
+package splash
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/alecthomas/chroma/v2"
+)
+
+var (
+	// ErrNoHead is returned when CSS should be added to HTML that has neither <head> nor <html>
+	ErrNoHead = errors.New("HTML should contain <head> or <html> when adding CSS")
+	// ErrTokenise is wrapped by a *BlockError when the lexer could not tokenise the code
+	ErrTokenise = errors.New("could not tokenise the code")
+	// ErrFormat is wrapped by a *BlockError when the highlighted code could not be written as HTML
+	ErrFormat = errors.New("could not format the code")
+	// ErrPanic is wrapped by a *BlockError when highlighting a block panicked, in isolation mode
+	ErrPanic = errors.New("panic while highlighting the code")
+)
+
+// BlockError is returned when a block of code could not be highlighted
+type BlockError struct {
+	Index    int    // the position of the block in the document, from 0
+	Offset   int    // the byte offset of the block in the input
+	Language string // the declared or chosen language of the block, if any
+	Err      error  // the underlying error
+}
+
+func (e *BlockError) Error() string {
+	if e.Language == "" {
+		return fmt.Sprintf("block %d at offset %d: %v", e.Index, e.Offset, e.Err)
+	}
+	return fmt.Sprintf("block %d at offset %d (%s): %v", e.Index, e.Offset, e.Language, e.Err)
+}
+
+func (e *BlockError) Unwrap() error {
+	return e.Err
+}
+
+// BlockErrors is returned in isolation mode, together with the highlighted
+// HTML, when one or more blocks could not be highlighted
+type BlockErrors []*BlockError
+
+func (e BlockErrors) Error() string {
+	if len(e) == 1 {
+		return e[0].Error()
+	}
+	msgs := make([]string, len(e))
+	for i, err := range e {
+		msgs[i] = err.Error()
+	}
+	return fmt.Sprintf("%d blocks could not be highlighted:\n%s", len(e), strings.Join(msgs, "\n"))
+}
+
+func (e BlockErrors) Unwrap() []error {
+	errs := make([]error, len(e))
+	for i, err := range e {
+		errs[i] = err
+	}
+	return errs
+}
+
+// asError returns the errors as an error, or nil if there are none
+func (e BlockErrors) asError() error {
+	if len(e) == 0 {
+		return nil
+	}
+	return e
+}
+
+// WithErrorIsolation can be set to true for leaving blocks of code that can
+// not be highlighted as they were, instead of failing for the entire document.
+// The errors for the blocks are then returned as BlockErrors, together with
+// the HTML and CSS. Panics while highlighting a block are also turned into
+// errors, that wrap ErrPanic.
+func WithErrorIsolation(isolate bool) Option {
+	return func(h *Highlighter) {
+		h.isolateErrors = isolate
+	}
+}
+
+// renderBlock highlights the block with the given index. Blocks that exceed
+// the limits are returned as plain text. If it fails, a *BlockError is
+// returned, together with the block as it was in the input.
+func (h *Highlighter) renderBlock(ctx context.Context, style *chroma.Style, block *codeBlock, index int) (*renderedBlock, *BlockError) {
+	if limitErr := h.checkLimits(block, index); limitErr != nil {
+		return h.plainBlock(style, block, limitErr), nil
+	}
+	rendered, err := h.safeHighlightBlock(ctx, style, block)
+	if err == nil {
+		return rendered, nil
+	}
+	var limitErr *LimitError
+	if errors.As(err, &limitErr) {
+		limitErr.Index, limitErr.Offset = index, block.offset
+		return h.plainBlock(style, block, limitErr), nil
+	}
+	blockErr := &BlockError{Index: index, Offset: block.offset, Language: h.blockLanguage(block), Err: err}
+	return &renderedBlock{html: block.raw, style: style}, blockErr
+}
+
+// safeHighlightBlock highlights the block, and turns panics into errors in isolation mode
+func (h *Highlighter) safeHighlightBlock(ctx context.Context, style *chroma.Style, block *codeBlock) (rendered *renderedBlock, err error) {
+	if h.isolateErrors {
+		defer func() {
+			if r := recover(); r != nil {
+				rendered, err = nil, fmt.Errorf("%w: %v", ErrPanic, r)
+			}
+		}()
+	}
+	return h.highlightBlock(ctx, style, block)
+}
+
+// blockLanguage returns the declared language of the block, or else the
+// name of the lexer that would be chosen for it
+func (h *Highlighter) blockLanguage(block *codeBlock) string {
+	if language := block.declaredLanguage(); language != "" || block.wrapper == wrapInline {
+		return language
+	}
+	lexer, _ := h.findLexer(block, h.blockSource(block))
+	return lexer.Config().Name
+}
@@ -0,0 +1,106 @@
+package splash
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/alecthomas/chroma/v2"
+	"github.com/alecthomas/chroma/v2/lexers"
+)
+
+// brokenLexer is a lexer that either fails or panics when tokenising
+type brokenLexer struct {
+	name   string
+	panics bool
+}
+
+func (l *brokenLexer) Config() *chroma.Config {
+	return &chroma.Config{Name: l.name, Aliases: []string{l.name}}
+}
+
+func (l *brokenLexer) Tokenise(*chroma.TokeniseOptions, string) (chroma.Iterator, error) {
+	if l.panics {
+		panic("the lexer is broken")
+	}
+	return nil, errors.New("the lexer is broken")
+}
+
+func (l *brokenLexer) SetRegistry(*chroma.LexerRegistry) chroma.Lexer     { return l }
+func (l *brokenLexer) SetAnalyser(func(text string) float32) chroma.Lexer { return l }
+func (l *brokenLexer) AnalyseText(string) float32                         { return 0 }
+
+func init() {
+	lexers.Register(&brokenLexer{name: "splash-broken"})
+	lexers.Register(&brokenLexer{name: "splash-panic", panics: true})
+}
+
+const brokenInput = "<html><head></head><body>" +
+	"<pre class=\"language-go\">x := 1</pre>" +
+	"<pre class=\"language-splash-broken\">a &lt; b</pre>" +
+	"<pre class=\"language-splash-panic\">c</pre>" +
+	"<pre class=\"language-go\">y := 2</pre>" +
+	"</body></html>"
+
+func TestBlockError(t *testing.T) {
+	_, _, err := New().Highlight([]byte(brokenInput))
+	var blockErr *BlockError
+	if !errors.As(err, &blockErr) {
+		t.Fatalf("expected a *BlockError, got %v", err)
+	}
+	if blockErr.Index != 1 || blockErr.Offset != strings.Index(brokenInput, "<pre class=\"language-splash-broken\">") || blockErr.Language != "splash-broken" {
+		t.Errorf("unexpected block error: %+v", blockErr)
+	}
+	if !errors.Is(err, ErrTokenise) {
+		t.Errorf("expected the error to wrap ErrTokenise, got %v", err)
+	}
+}
+
+func TestErrorIsolation(t *testing.T) {
+	htmlData, cssData, err := New(WithErrorIsolation(true)).Highlight([]byte(brokenInput))
+	var blockErrs BlockErrors
+	if !errors.As(err, &blockErrs) || len(blockErrs) != 2 {
+		t.Fatalf("expected two block errors, got %v", err)
+	}
+	if blockErrs[0].Index != 1 || !errors.Is(blockErrs[0], ErrTokenise) {
+		t.Errorf("expected the second block to fail to tokenise, got %v", blockErrs[0])
+	}
+	if blockErrs[1].Index != 2 || !errors.Is(blockErrs[1], ErrPanic) {
+		t.Errorf("expected the third block to panic, got %v", blockErrs[1])
+	}
+	if !errors.Is(err, ErrPanic) {
+		t.Errorf("expected BlockErrors to wrap ErrPanic")
+	}
+	if len(cssData) == 0 {
+		t.Error("expected CSS for the blocks that were highlighted")
+	}
+	if !bytes.Contains(htmlData, []byte("<pre class=\"language-splash-broken\">a &lt; b</pre>")) {
+		t.Errorf("expected the failing block to be left as it was, got %s", htmlData)
+	}
+	if bytes.Count(htmlData, []byte(" chroma\"")) != 2 {
+		t.Errorf("expected the other blocks to be highlighted, got %s", htmlData)
+	}
+
+	splashed, err := New(WithErrorIsolation(true)).Splash([]byte(brokenInput))
+	if !errors.As(err, &blockErrs) || !bytes.Contains(splashed, []byte("<style>")) {
+		t.Errorf("expected Splash to return the HTML with CSS together with the block errors, got %v", err)
+	}
+
+	var buf bytes.Buffer
+	err = New(WithErrorIsolation(true)).SplashStream(&buf, strings.NewReader(brokenInput))
+	if !errors.As(err, &blockErrs) || len(blockErrs) != 2 || !strings.HasSuffix(buf.String(), "</body></html>") {
+		t.Errorf("expected SplashStream to write all the HTML and return the block errors, got %v", err)
+	}
+
+	_, _, report, _ := New(WithErrorIsolation(true)).HighlightReport([]byte(brokenInput))
+	if len(report.Blocks) != 4 || report.Blocks[1].Err == nil || report.Blocks[0].Err != nil {
+		t.Errorf("expected the report to include the failing blocks, got %+v", report.Blocks)
+	}
+}
+
+func TestErrNoHead(t *testing.T) {
+	if _, err := AddCSSToHTML([]byte("<p>hi</p>"), []byte("pre{}")); !errors.Is(err, ErrNoHead) {
+		t.Errorf("expected ErrNoHead, got %v", err)
+	}
+}
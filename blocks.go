@@ -0,0 +1,412 @@
+package splash
+
+import (
+	"bytes"
+	"io"
+	"slices"
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+// wrapper describes how the source code of a block is wrapped in HTML tags
+type wrapper int
+
+const (
+	wrapPre     wrapper = iota // <pre>...</pre>
+	wrapPreCode                // <pre><code>...</code></pre>
+	wrapCodePre                // <code><pre>...</pre></code>
+	wrapInline                 // <code>...</code>, outside of <pre>
+)
+
+// rawToken is a token from the HTML tokenizer, together with a copy of the
+// exact bytes it was read from.
+type rawToken struct {
+	token html.Token
+	raw   []byte
+}
+
+// codeBlock is a block of source code that was found in the HTML.
+type codeBlock struct {
+	offset  int       // byte offset of the block in the HTML
+	raw     []byte    // the entire block, exactly as it appeared in the HTML
+	wrapper wrapper   // how the source code is wrapped
+	outer   rawToken  // the outermost start tag
+	inner   *rawToken // the inner start tag, nil for wrapPre
+	source  string    // the text content of the block, with any nested tags removed
+	tooBig  int       // if not 0, the block is larger than the maximum size, and this many bytes were read before raw was cut off after the start tag
+}
+
+// blockScanner reads HTML from an io.Reader and splits it into code blocks
+// and the HTML between them, which should be passed through unmodified.
+type blockScanner struct {
+	z          *html.Tokenizer
+	offset     int                   // byte offset of the next token that is returned by next
+	pending    []rawToken            // tokens that have been read ahead and pushed back, the next one last
+	unclosed   map[int]bool          // offsets of <pre> and <code> start tags that are not closed before the HTML ends
+	skipUntil  int                   // start tags before this offset are within a block that is too big, and are passed through
+	maxSize    int                   // the maximum size of a block, including the tags, or 0 for no limit
+	err        error                 // the error returned by the tokenizer, if any
+	inlineCode func(*codeBlock) bool // decides which <code> elements outside of <pre> are returned as blocks, or nil for none
+}
+
+// newBlockScanner creates a new blockScanner that reads HTML from r.
+// <code> elements outside of <pre> are also returned as blocks if inlineCode
+// is not nil and returns true for them. Blocks that are larger than maxSize
+// bytes are not read into memory, unless maxSize is 0.
+func newBlockScanner(r io.Reader, inlineCode func(*codeBlock) bool, maxSize int) *blockScanner {
+	return &blockScanner{z: html.NewTokenizer(r), inlineCode: inlineCode, maxSize: maxSize}
+}
+
+// next returns the next token, or false if there are no more tokens
+func (s *blockScanner) next() (rawToken, bool) {
+	if n := len(s.pending); n > 0 {
+		t := s.pending[n-1]
+		s.pending = s.pending[:n-1]
+		return t, true
+	}
+	if s.err != nil {
+		return rawToken{}, false
+	}
+	if s.z.Next() == html.ErrorToken {
+		s.err = s.z.Err()
+		return rawToken{}, false
+	}
+	// Raw must be copied before calling Token, since Token may modify the buffer
+	raw := append([]byte(nil), s.z.Raw()...)
+	return rawToken{token: s.z.Token(), raw: raw}, true
+}
+
+// pushBack makes the given tokens be returned by next again, in order
+func (s *blockScanner) pushBack(tokens []rawToken) {
+	for i := len(tokens) - 1; i >= 0; i-- {
+		s.pending = append(s.pending, tokens[i])
+	}
+}
+
+// Scan returns either a token of HTML that should be passed through as it is,
+// or a code block. Returns io.EOF when there is no more HTML to read. A block
+// that is larger than the maximum size is returned with only its start tag
+// in raw, and the rest of it is then returned as HTML to pass through.
+func (s *blockScanner) Scan() (rawToken, *codeBlock, error) {
+	t, ok := s.next()
+	if !ok {
+		return rawToken{}, nil, s.err
+	}
+	offset := s.offset
+	if t.token.Type == html.StartTagToken && !s.unclosed[offset] && offset >= s.skipUntil {
+		switch t.token.Data {
+		case "pre", "code":
+			if block, ok := s.scanBlock(t, t.token.Data, offset); ok {
+				block.offset = offset
+				s.offset += len(block.raw)
+				return rawToken{}, block, nil
+			}
+		}
+	}
+	s.offset += len(t.raw)
+	return t, nil, nil
+}
+
+// scanBlock tries to read a code block that starts with the given start tag.
+// If no code block could be read, all read tokens are pushed back and false is returned.
+func (s *blockScanner) scanBlock(start rawToken, tagName string, offset int) (*codeBlock, bool) {
+	body, ok, size := s.readElement(tagName, len(start.raw))
+	if !ok && s.maxSize > 0 && size > s.maxSize {
+		// Pass the tokens that were read through, without looking for blocks among them
+		s.skipUntil = offset + size
+		s.pushBack(body)
+		first, _ := trimWhitespaceTokens(body)
+		if tagName == "pre" || (first < len(body) && isStartTag(body[first], "pre")) {
+			return &codeBlock{raw: start.raw, wrapper: wrapPre, outer: start, tooBig: size}, true
+		}
+		return nil, false
+	}
+	if !ok {
+		s.markUnclosed(body, offset+len(start.raw))
+		s.pushBack(body)
+		return nil, false
+	}
+	// body now contains the tokens between the start tag and the end tag
+	content := body[:len(body)-1]
+	raw := joinRaw(append([]rawToken{start}, body...))
+
+	// Check if the start tag is directly followed by an inner <code> or <pre> element
+	first, last := trimWhitespaceTokens(content)
+	innerName := "code"
+	if tagName == "code" {
+		innerName = "pre"
+	}
+	if last-first >= 2 && isStartTag(content[first], innerName) && isEndTag(content[last-1], innerName) && closes(content[first+1:last], innerName) {
+		inner := content[first]
+		w := wrapPreCode
+		if tagName == "code" {
+			w = wrapCodePre
+		}
+		return &codeBlock{
+			raw:     raw,
+			wrapper: w,
+			outer:   start,
+			inner:   &inner,
+			source:  textContent(content[first+1:last-1], innerName == "pre"),
+		}, true
+	}
+
+	if tagName == "code" {
+		if s.inlineCode != nil && !slices.ContainsFunc(content, func(t rawToken) bool { return isStartTag(t, "pre") }) {
+			block := &codeBlock{
+				raw:     raw,
+				wrapper: wrapInline,
+				outer:   start,
+				source:  textContent(content, false),
+			}
+			if s.inlineCode(block) {
+				return block, true
+			}
+		}
+		// An inline <code> element that is not highlighted, or <code> that does
+		// not directly contain <pre>. Push back the contents, so that any <pre>
+		// elements within are found.
+		s.pushBack(body)
+		return nil, false
+	}
+
+	return &codeBlock{
+		raw:     raw,
+		wrapper: wrapPre,
+		outer:   start,
+		source:  textContent(content, true),
+	}, true
+}
+
+// readElement reads tokens until the end tag that matches an already read
+// start tag with the given name and size. The end tag is the last returned
+// token. Returns false if the HTML ended before the end tag was found, or if
+// the element is larger than the maximum size, together with the number of
+// bytes that were read, including the start tag.
+func (s *blockScanner) readElement(tagName string, size int) ([]rawToken, bool, int) {
+	var tokens []rawToken
+	depth := 1
+	for {
+		t, ok := s.next()
+		if !ok {
+			return tokens, false, size
+		}
+		tokens = append(tokens, t)
+		size += len(t.raw)
+		if s.maxSize > 0 && size > s.maxSize {
+			return tokens, false, size
+		}
+		if isStartTag(t, tagName) {
+			depth++
+		} else if isEndTag(t, tagName) {
+			depth--
+			if depth == 0 {
+				return tokens, true, size
+			}
+		}
+	}
+}
+
+// markUnclosed is called when the HTML ended before the end tag of a block,
+// with the tokens that were read until then, starting at the given offset.
+// All the remaining tokens have then been read, so the <pre> and <code> start
+// tags among them that are never closed are found in one pass, instead of
+// reading to the end of the HTML again for each one of them.
+func (s *blockScanner) markUnclosed(tokens []rawToken, offset int) {
+	if s.unclosed == nil {
+		s.unclosed = make(map[int]bool)
+	}
+	open := make(map[string][]int)
+	for _, t := range tokens {
+		switch {
+		case isStartTag(t, "pre") || isStartTag(t, "code"):
+			open[t.token.Data] = append(open[t.token.Data], offset)
+		case isEndTag(t, "pre") || isEndTag(t, "code"):
+			if starts := open[t.token.Data]; len(starts) > 0 {
+				open[t.token.Data] = starts[:len(starts)-1]
+			}
+		}
+		offset += len(t.raw)
+	}
+	for _, starts := range open {
+		for _, start := range starts {
+			s.unclosed[start] = true
+		}
+	}
+}
+
+// isStartTag checks if the given token is a start tag with the given name
+func isStartTag(t rawToken, tagName string) bool {
+	return t.token.Type == html.StartTagToken && t.token.Data == tagName
+}
+
+// isEndTag checks if the given token is an end tag with the given name
+func isEndTag(t rawToken, tagName string) bool {
+	return t.token.Type == html.EndTagToken && t.token.Data == tagName
+}
+
+// closes checks if the given tokens start with an element that is closed by
+// the very last token, and not before that.
+func closes(tokens []rawToken, tagName string) bool {
+	depth := 1
+	for i, t := range tokens {
+		if isStartTag(t, tagName) {
+			depth++
+		} else if isEndTag(t, tagName) {
+			depth--
+			if depth == 0 {
+				return i == len(tokens)-1
+			}
+		}
+	}
+	return false
+}
+
+// trimWhitespaceTokens returns the range of tokens that are left after
+// skipping leading and trailing text tokens that only contain whitespace.
+func trimWhitespaceTokens(tokens []rawToken) (int, int) {
+	isBlank := func(t rawToken) bool {
+		return t.token.Type == html.TextToken && len(bytes.TrimSpace(t.raw)) == 0
+	}
+	first, last := 0, len(tokens)
+	for first < last && isBlank(tokens[first]) {
+		first++
+	}
+	for last > first && isBlank(tokens[last-1]) {
+		last--
+	}
+	return first, last
+}
+
+// textContent returns the raw text of the given tokens, leaving out any tags
+// and comments. Entities are not unescaped. If afterPre is true, a single
+// leading newline is removed, the same way as browsers do right after <pre>.
+func textContent(tokens []rawToken, afterPre bool) string {
+	var sb strings.Builder
+	for _, t := range tokens {
+		if t.token.Type == html.TextToken {
+			sb.Write(t.raw)
+		}
+	}
+	text := sb.String()
+	if afterPre {
+		if strings.HasPrefix(text, "\r\n") {
+			text = text[2:]
+		} else if strings.HasPrefix(text, "\n") {
+			text = text[1:]
+		}
+	}
+	return text
+}
+
+// joinRaw concatenates the raw bytes of the given tokens
+func joinRaw(tokens []rawToken) []byte {
+	var buf bytes.Buffer
+	for _, t := range tokens {
+		buf.Write(t.raw)
+	}
+	return buf.Bytes()
+}
+
+// attr returns the value of the attribute with the given name, if present
+func attr(t html.Token, key string) (string, bool) {
+	for _, a := range t.Attr {
+		if a.Namespace == "" && a.Key == key {
+			return a.Val, true
+		}
+	}
+	return "", false
+}
+
+// classes returns the CSS classes of the given tag
+func classes(t html.Token) []string {
+	val, _ := attr(t, "class")
+	return strings.Fields(val)
+}
+
+// withAttrs returns the given start tag as HTML, with the given CSS classes
+// added, and the given inline style placed before any existing inline style.
+func withAttrs(t html.Token, class, style string) string {
+	attrs := make([]html.Attribute, 0, len(t.Attr)+2)
+	foundClass, foundStyle := class == "", style == ""
+	for _, a := range t.Attr {
+		if a.Namespace == "" && a.Key == "class" && !foundClass {
+			existing := strings.Fields(a.Val)
+			for _, c := range strings.Fields(class) {
+				if !slices.Contains(existing, c) {
+					a.Val = strings.TrimSpace(a.Val + " " + c)
+				}
+			}
+			foundClass = true
+		} else if a.Namespace == "" && a.Key == "style" && !foundStyle {
+			a.Val = strings.TrimSuffix(style, ";") + "; " + a.Val
+			foundStyle = true
+		}
+		attrs = append(attrs, a)
+	}
+	if !foundClass {
+		attrs = append(attrs, html.Attribute{Key: "class", Val: class})
+	}
+	if !foundStyle {
+		attrs = append(attrs, html.Attribute{Key: "style", Val: style})
+	}
+	t.Attr = attrs
+	return t.String()
+}
+
+// attr returns the value of the given attribute on the inner tag of the
+// block, or on the outer tag if the inner tag does not have it.
+func (b *codeBlock) attr(key string) (string, bool) {
+	if b.inner != nil {
+		if val, ok := attr(b.inner.token, key); ok {
+			return val, true
+		}
+	}
+	return attr(b.outer.token, key)
+}
+
+// language returns the language given by a "language-" class on the inner
+// or outer tag of the block, or an empty string.
+func (b *codeBlock) language() string {
+	tags := []html.Token{b.outer.token}
+	if b.inner != nil {
+		tags = []html.Token{b.inner.token, b.outer.token}
+	}
+	for _, t := range tags {
+		for _, c := range classes(t) {
+			if lang := strings.TrimPrefix(c, "language-"); lang != c && lang != "" {
+				return lang
+			}
+		}
+	}
+	return ""
+}
+
+// wrap surrounds the highlighted code with the same tags as the original
+// block, while adding the given CSS classes and inline style to the <pre>
+// tag, or to the <code> tag of inline code.
+func (b *codeBlock) wrap(highlighted []byte, class, preStyle string) []byte {
+	var buf bytes.Buffer
+	switch b.wrapper {
+	case wrapPreCode:
+		buf.WriteString(withAttrs(b.outer.token, class, preStyle))
+		buf.Write(b.inner.raw)
+		buf.Write(highlighted)
+		buf.WriteString("</code></pre>")
+	case wrapCodePre:
+		buf.Write(b.outer.raw)
+		buf.WriteString(withAttrs(b.inner.token, class, preStyle))
+		buf.Write(highlighted)
+		buf.WriteString("</pre></code>")
+	case wrapInline:
+		buf.WriteString(withAttrs(b.outer.token, class, preStyle))
+		buf.Write(highlighted)
+		buf.WriteString("</code>")
+	default:
+		buf.WriteString(withAttrs(b.outer.token, class, preStyle))
+		buf.Write(highlighted)
+		buf.WriteString("</pre>")
+	}
+	return buf.Bytes()
+}
@@ -0,0 +1,141 @@
+package splash
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/alecthomas/chroma/v2"
+)
+
+// cssReset is used for the rules that a style overriding the document style
+// does not have, so that the rules of the document style do not apply.
+const cssReset = "color: inherit; background-color: transparent; font-weight: inherit; font-style: inherit; text-decoration: inherit"
+
+// resetValues are the values in cssReset, per property, for the properties
+// that a rule of a style overriding the document style does not set
+var resetValues = map[string]string{
+	"color":            "inherit",
+	"background-color": "transparent",
+	"font-weight":      "inherit",
+	"font-style":       "inherit",
+	"text-decoration":  "inherit",
+}
+
+// blockStyle returns the style for the given block. The style of the document
+// can be overridden per block with a data-style or data-theme attribute on
+// <pre> or <code>, like <pre data-style="github">.
+func (h *Highlighter) blockStyle(style *chroma.Style, block *codeBlock) (*chroma.Style, error) {
+	styleName, ok := block.attr("data-style")
+	if !ok {
+		styleName, ok = block.attr("data-theme")
+	}
+	if !ok || strings.TrimSpace(styleName) == "" {
+		return style, nil
+	}
+	return h.style(strings.TrimSpace(styleName))
+}
+
+// blockClass returns the CSS classes for the <pre> tag of a block with the
+// given style, where style is the document style.
+func (h *Highlighter) blockClass(style, blockStyle *chroma.Style) string {
+	if blockStyle == style {
+		return h.classPrefix + "chroma"
+	}
+	return h.classPrefix + "chroma " + h.classPrefix + scopeClass(blockStyle)
+}
+
+// scopeClass returns the CSS class that is added to blocks with a style that
+// overrides the document style, like "style-github" for "github". The class
+// prefix is added in front of it, like for the classes from chroma.
+func scopeClass(style *chroma.Style) string {
+	var sb strings.Builder
+	sb.WriteString("style-")
+	dash := false
+	for _, r := range strings.ToLower(style.Name) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			sb.WriteRune(r)
+			dash = false
+		} else if !dash {
+			sb.WriteRune('-')
+			dash = true
+		}
+	}
+	return strings.TrimSuffix(sb.String(), "-")
+}
+
+// scopeCSS changes the CSS for a style that overrides the document style, so
+// that it only applies to blocks with the given scope class. The rules from
+// the CSS for the document style that are missing are reset, and so are the
+// properties that a rule for the document style sets, while the rule for the
+// overriding style does not. If the scope is empty, the selectors are kept as
+// they are. The class prefix is the one that was used when generating the CSS.
+func scopeCSS(cssData []byte, scope string, documentCSS []byte, classPrefix string) []byte {
+	var (
+		rules     []cssRule
+		overrides = make(map[string]int) // selector -> index in rules
+	)
+	scoped := func(selector string) string {
+		if scope == "" {
+			return selector
+		}
+		for _, outer := range []string{"." + classPrefix + "chroma", "." + classPrefix + "bg"} {
+			if rest, found := strings.CutPrefix(selector, outer); found && (rest == "" || rest[0] == ' ' || rest[0] == ':') {
+				return outer + "." + scope + rest
+			}
+		}
+		return "." + scope + " " + selector
+	}
+	for _, rule := range parseCSS(cssData) {
+		overrides[rule.selector] = len(rules)
+		rule.selector = scoped(rule.selector)
+		rules = append(rules, rule)
+	}
+	for _, rule := range parseCSS(documentCSS) {
+		i, found := overrides[rule.selector]
+		if !found && rule.class() != "" {
+			rule.selector = scoped(rule.selector)
+			rule.body = cssReset
+			rules = append(rules, rule)
+			continue
+		}
+		var override cssRule
+		if found {
+			override = rules[i]
+		}
+		var resets []string
+		for _, property := range rule.properties() {
+			value, ok := resetValues[property]
+			if !ok || override.value(property) != "" {
+				continue
+			}
+			if property == "color" && rule.class() == "" {
+				// The text must stay readable on the background of the overriding style
+				value = contrastingColour(override.value("background-color"))
+			}
+			resets = append(resets, property+": "+value)
+		}
+		switch {
+		case len(resets) == 0:
+		case found:
+			rules[i].body = strings.Join(append([]string{strings.TrimRight(rules[i].body, "; ")}, resets...), "; ")
+		default:
+			rule.selector = scoped(rule.selector)
+			rule.body = strings.Join(resets, "; ")
+			rules = append(rules, rule)
+		}
+	}
+	return writeCSS(rules)
+}
+
+// contrastingColour returns black or white, whichever has the most contrast
+// against the given background color, or "inherit" if there is no color
+func contrastingColour(background string) string {
+	bg := chroma.ParseColour(background)
+	if !bg.IsSet() {
+		return "inherit"
+	}
+	if ContrastRatio(bg, black) >= ContrastRatio(bg, white) {
+		return black.String()
+	}
+	return white.String()
+}
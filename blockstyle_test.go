@@ -0,0 +1,66 @@
+package splash
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestBlockStyle(t *testing.T) {
+	input := []byte(`<pre data-style="github"><code class="language-go">func main() {}</code></pre>` +
+		`<pre><code class="language-sh" data-theme="monokai">ls -l</code></pre>` +
+		`<pre data-style="github">x := 1</pre>`)
+
+	htmlBytes, cssBytes, err := New(WithStyle("monokai")).Highlight(input)
+	if err != nil {
+		t.Fatal(err)
+	}
+	output := string(htmlBytes)
+	assertEqual(t, 2, strings.Count(output, `<pre data-style="github" class="chroma style-github">`), "expected the github blocks to be scoped: "+output)
+	assertEqual(t, 1, strings.Count(output, `<pre class="chroma">`), "expected the monokai block to not be scoped: "+output)
+
+	// The github CSS is only included once, and is scoped
+	assertEqual(t, 1, bytes.Count(cssBytes, []byte(".chroma.style-github {")), "expected scoped CSS for github: "+string(cssBytes))
+	if !bytes.Contains(cssBytes, []byte(".chroma.style-github .kd {")) || !bytes.Contains(cssBytes, []byte(".chroma .kd {")) {
+		t.Errorf("expected CSS for both styles: %s", cssBytes)
+	}
+
+	// Streaming writes the scoped CSS right before the first block that needs it
+	var buf bytes.Buffer
+	if err := SplashStream(&buf, bytes.NewReader(input), "monokai"); err != nil {
+		t.Fatal(err)
+	}
+	streamed := buf.String()
+	assertEqual(t, 2, strings.Count(streamed, "<style>"), "expected two <style> tags: "+streamed)
+	if !strings.Contains(streamed, "</style><pre data-style=\"github\" class=\"chroma style-github\">") {
+		t.Errorf("expected the scoped CSS before the first github block: %s", streamed)
+	}
+}
+
+func TestScopeCSS(t *testing.T) {
+	documentCSS := []byte("/* Background */ .bg { color: #fff; background-color: #222 }\n/* PreWrapper */ .chroma { color: #fff; background-color: #222 }\n/* Keyword */ .chroma .k { color: #f00; font-weight: bold }\n/* Name */ .chroma .n { color: #0f0 }\n")
+	overrideCSS := []byte("/* PreWrapper */ .chroma { background-color: #fff }\n/* Keyword */ .chroma .k { color: #00f }\n")
+	scoped := string(scopeCSS(overrideCSS, "style-x", documentCSS, ""))
+	assertEqual(t, "/* PreWrapper */ .chroma.style-x { background-color: #fff; color: #000000 }\n"+
+		"/* Keyword */ .chroma.style-x .k { color: #00f; font-weight: inherit }\n"+
+		"/* Background */ .bg.style-x { color: inherit; background-color: transparent }\n"+
+		"/* Name */ .chroma.style-x .n { "+cssReset+" }\n", scoped, "")
+}
+
+func TestScopeCSSKeepsTextVisible(t *testing.T) {
+	// github does not set a color for plain text, so the light text from monokai must not be used
+	input := []byte(`<pre class="language-text" data-style="github">plain</pre>`)
+	_, cssBytes, err := New(WithStyle("monokai")).Highlight(input)
+	if err != nil {
+		t.Fatal(err)
+	}
+	css := string(cssBytes)
+	start := strings.Index(css, ".chroma.style-github {")
+	if start < 0 {
+		t.Fatalf("expected a scoped .chroma rule: %s", css)
+	}
+	body := css[start : start+strings.Index(css[start:], "}")]
+	if !strings.Contains(strings.ReplaceAll(body, "background-color", ""), "color:") {
+		t.Errorf("expected the scoped .chroma rule to set the color: %s", body)
+	}
+}
@@ -0,0 +1,96 @@
+package splash
+
+import (
+	"bytes"
+	"sync"
+
+	"github.com/alecthomas/chroma/v2"
+	chromaHTML "github.com/alecthomas/chroma/v2/formatters/html"
+)
+
+// stylesheetCacheLimit is the maximum number of stylesheets that are cached
+const stylesheetCacheLimit = 64
+
+// sharedFormatters holds one formatter per formatterConfig. These are shared
+// by all Highlighters that have no extra formatter options, so that their
+// stylesheets can be cached together.
+var sharedFormatters sync.Map
+
+// sharedFormatter returns the shared formatter for the given configuration
+func sharedFormatter(config formatterConfig) *chromaHTML.Formatter {
+	if formatter, ok := sharedFormatters.Load(config); ok {
+		return formatter.(*chromaHTML.Formatter)
+	}
+	formatter, _ := sharedFormatters.LoadOrStore(config, newFormatter(config))
+	return formatter.(*chromaHTML.Formatter)
+}
+
+// stylesheets is the process-wide cache of generated stylesheets
+var stylesheets = newStylesheetCache()
+
+// stylesheetKey identifies a generated stylesheet. The style is keyed by
+// pointer, so that a style that is registered again under the same name
+// is not mixed up with the old one.
+type stylesheetKey struct {
+	style     *chroma.Style
+	formatter *chromaHTML.Formatter
+}
+
+// stylesheet is the CSS generated by chroma, both as it is and stripped
+type stylesheet struct {
+	css      []byte // the CSS as generated by chroma, with one rule per line
+	stripped []byte // the CSS without comments and newlines
+}
+
+// stylesheetCache is a goroutine-safe cache of generated stylesheets.
+// When the cache is full, the oldest entry is evicted.
+type stylesheetCache struct {
+	mu      sync.RWMutex
+	entries map[stylesheetKey]*stylesheet
+	order   []stylesheetKey
+}
+
+// newStylesheetCache creates a new and empty stylesheetCache
+func newStylesheetCache() *stylesheetCache {
+	return &stylesheetCache{entries: make(map[stylesheetKey]*stylesheet)}
+}
+
+// get returns the stylesheet for the given formatter and style, generating it if needed
+func (c *stylesheetCache) get(formatter *chromaHTML.Formatter, style *chroma.Style) (*stylesheet, error) {
+	key := stylesheetKey{style: style, formatter: formatter}
+
+	c.mu.RLock()
+	sheet, ok := c.entries[key]
+	c.mu.RUnlock()
+	if ok {
+		return sheet, nil
+	}
+
+	var cssBuf bytes.Buffer
+	if err := formatter.WriteCSS(&cssBuf, style); err != nil {
+		return nil, err
+	}
+	sheet = &stylesheet{css: cssBuf.Bytes(), stripped: stripCSS(cssBuf.Bytes())}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if existing, ok := c.entries[key]; ok {
+		// Another goroutine generated the same stylesheet in the meantime
+		return existing, nil
+	}
+	if len(c.order) >= stylesheetCacheLimit {
+		delete(c.entries, c.order[0])
+		c.order = c.order[1:]
+	}
+	c.entries[key] = sheet
+	c.order = append(c.order, key)
+	return sheet, nil
+}
+
+// reset removes all cached stylesheets
+func (c *stylesheetCache) reset() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries = make(map[stylesheetKey]*stylesheet)
+	c.order = nil
+}
@@ -0,0 +1,92 @@
+package splash
+
+import (
+	"bytes"
+	"sync"
+	"testing"
+
+	chromaHTML "github.com/alecthomas/chroma/v2/formatters/html"
+)
+
+func TestStylesheetCache(t *testing.T) {
+	stylesheets.reset()
+
+	input := []byte("<pre><code class=\"language-go\">func main() {}</code></pre>")
+	var wg sync.WaitGroup
+	results := make([][]byte, 16)
+	for i := range results {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			_, cssBytes, err := Highlight(input, "monokai", false)
+			if err != nil {
+				t.Error(err)
+			}
+			results[i] = cssBytes
+		}(i)
+	}
+	wg.Wait()
+
+	for _, cssBytes := range results {
+		assertEqual(t, string(results[0]), string(cssBytes), "cached CSS differs")
+	}
+	assertEqual(t, 1, len(stylesheets.entries), "expected a single cached stylesheet")
+
+	// Modifying the returned CSS must not modify the cache
+	results[0][0] = 'X'
+	_, cssBytes, err := Highlight(input, "monokai", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertEqual(t, string(results[1]), string(cssBytes), "the cached CSS was modified")
+
+	// Other formatter options give another stylesheet
+	_, tabCSS, err := New(WithStyle("monokai"), WithFormatterOptions(chromaHTML.TabWidth(4))).Highlight(input)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bytes.Equal(tabCSS, cssBytes) || !bytes.Contains(tabCSS, []byte("tab-size: 4")) {
+		t.Errorf("expected different CSS for different formatter options: %s", tabCSS)
+	}
+	assertEqual(t, 2, len(stylesheets.entries), "expected two cached stylesheets")
+}
+
+func TestStylesheetCacheWithFormatterOptions(t *testing.T) {
+	stylesheets.reset()
+
+	// Blocks with line numbers need the CSS from another formatter, which must
+	// not be created again for each document, or the cache would never be hit
+	h := New(WithFormatterOptions(chromaHTML.TabWidth(4)))
+	input := []byte(`<pre data-line-numbers="table"><code class="language-go">func main() {}</code></pre>`)
+	var first []byte
+	for i := range 3 {
+		_, cssBytes, err := h.Highlight(input)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if i == 0 {
+			first = cssBytes
+		}
+		assertEqual(t, string(first), string(cssBytes), "cached CSS differs")
+	}
+	assertEqual(t, 1, len(stylesheets.entries), "expected a single cached stylesheet")
+}
+
+func BenchmarkHighlight(b *testing.B) {
+	input := []byte(languageBlock)
+	for i := 0; i < b.N; i++ {
+		if _, _, err := Highlight(input, "monokai", true); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkHighlightUncached(b *testing.B) {
+	input := []byte(languageBlock)
+	for i := 0; i < b.N; i++ {
+		stylesheets.reset()
+		if _, _, err := New(WithStyle("monokai"), WithUnescape(true), WithFormatterOptions(chromaHTML.TabWidth(8))).Highlight(input); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
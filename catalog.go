@@ -0,0 +1,132 @@
+package splash
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/alecthomas/chroma/v2"
+	"github.com/alecthomas/chroma/v2/styles"
+)
+
+// StyleInfo describes a style that can be used for highlighting
+type StyleInfo struct {
+	FileName   string   // the filename without extension, like "aura-theme-dark"
+	Name       string   // the name of the style, like "Aura Theme Dark"
+	Aliases    []string // other names that the style can be found by
+	Background string   // the background color, like "#272822"
+	Foreground string   // the default text color, like "#f8f8f2"
+	Dark       bool     // true if the style has light text on a dark background
+	Palette    []string // the most characteristic colors of the style, without duplicates
+	Custom     bool     // true if the style has been registered with splash
+}
+
+// paletteTokenTypes are the token types whose colors make up the palette of a style
+var paletteTokenTypes = []chroma.TokenType{
+	chroma.Keyword,
+	chroma.NameFunction,
+	chroma.LiteralString,
+	chroma.LiteralNumber,
+	chroma.Comment,
+	chroma.NameBuiltin,
+	chroma.KeywordType,
+	chroma.NameClass,
+	chroma.Operator,
+	chroma.NameTag,
+}
+
+// Styles returns all the styles that can be used, both the ones that are built
+// into chroma and the ones that have been registered, sorted by filename.
+func Styles() []StyleInfo {
+	fileNames := make(map[*chroma.Style]string)
+	aliases := make(map[*chroma.Style][]string)
+	for _, file := range styleFiles {
+		if style, ok := styles.Registry[strings.ToLower(file.styleName)]; ok {
+			fileNames[style] = file.fileName
+		}
+	}
+
+	all := make(map[*chroma.Style]bool)
+	for _, style := range styles.Registry {
+		all[style] = false
+	}
+	customStyles.RLock()
+	for name, style := range customStyles.byName {
+		all[style] = true
+		if name != strings.ToLower(style.Name) {
+			aliases[style] = append(aliases[style], name)
+		}
+	}
+	customStyles.RUnlock()
+
+	infos := make([]StyleInfo, 0, len(all))
+	for style, custom := range all {
+		if !custom && isCustomStyle(style) {
+			continue
+		}
+		info := describeStyle(style)
+		info.Custom = custom
+		info.FileName = fileNames[style]
+		if info.FileName == "" {
+			// Use the first alias, which is the filename for styles registered from files
+			sort.Strings(aliases[style])
+			if len(aliases[style]) > 0 {
+				info.FileName = aliases[style][0]
+			} else {
+				info.FileName = strings.ReplaceAll(strings.ToLower(style.Name), " ", "-")
+			}
+		}
+		for _, alias := range append([]string{info.FileName}, aliases[style]...) {
+			if !strings.EqualFold(alias, style.Name) && !containsFold(info.Aliases, alias) {
+				info.Aliases = append(info.Aliases, alias)
+			}
+		}
+		infos = append(infos, info)
+	}
+	sort.Slice(infos, func(i, j int) bool { return infos[i].FileName < infos[j].FileName })
+	return infos
+}
+
+// isCustomStyle returns true if a registered style replaces the chroma style
+// with the same name
+func isCustomStyle(style *chroma.Style) bool {
+	custom := lookupCustomStyle(style.Name)
+	return custom != nil && custom != style
+}
+
+// containsFold returns true if the list contains the string, regardless of case
+func containsFold(list []string, s string) bool {
+	for _, item := range list {
+		if strings.EqualFold(item, s) {
+			return true
+		}
+	}
+	return false
+}
+
+// describeStyle returns the colors of the given style
+func describeStyle(style *chroma.Style) StyleInfo {
+	background := style.Get(chroma.Background)
+	bg, fg := background.Background, background.Colour
+	if !bg.IsSet() {
+		bg = white
+	}
+	if !fg.IsSet() {
+		fg = black
+	}
+	info := StyleInfo{
+		Name:       style.Name,
+		Background: bg.String(),
+		Foreground: fg.String(),
+		Dark:       relativeLuminance(bg) < relativeLuminance(fg),
+	}
+	for _, tokenType := range paletteTokenTypes {
+		colour := style.Get(tokenType).Colour
+		if !colour.IsSet() || colour == fg {
+			continue
+		}
+		if c := colour.String(); !containsFold(info.Palette, c) {
+			info.Palette = append(info.Palette, c)
+		}
+	}
+	return info
+}
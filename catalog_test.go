@@ -0,0 +1,57 @@
+package splash
+
+import (
+	"testing"
+
+	"github.com/alecthomas/chroma/v2"
+)
+
+func findStyleInfo(t *testing.T, fileName string) StyleInfo {
+	t.Helper()
+	for _, info := range Styles() {
+		if info.FileName == fileName {
+			return info
+		}
+	}
+	t.Fatalf("expected %s in the style catalog", fileName)
+	return StyleInfo{}
+}
+
+func TestStyles(t *testing.T) {
+	infos := Styles()
+	if len(infos) < 50 {
+		t.Fatalf("expected all the chroma styles, got %d", len(infos))
+	}
+	for i := 1; i < len(infos); i++ {
+		if infos[i-1].FileName >= infos[i].FileName {
+			t.Errorf("expected the styles to be sorted by filename: %s before %s", infos[i-1].FileName, infos[i].FileName)
+		}
+	}
+
+	monokai := findStyleInfo(t, "monokai")
+	assertEqual(t, "#272822", monokai.Background, "")
+	assertEqual(t, "#f8f8f2", monokai.Foreground, "")
+	assertEqual(t, true, monokai.Dark, "")
+	if len(monokai.Palette) < 3 {
+		t.Errorf("expected a palette with several colors, got %v", monokai.Palette)
+	}
+
+	github := findStyleInfo(t, "github")
+	assertEqual(t, false, github.Dark, "")
+
+	rpgle := findStyleInfo(t, "rpgle")
+	assertEqual(t, "RPGLE", rpgle.Name, "")
+}
+
+func TestStylesCustom(t *testing.T) {
+	if _, err := RegisterStyle("Test Catalog", map[chroma.TokenType]string{
+		chroma.Background: "bg:#000000 #ffffff",
+		chroma.Keyword:    "#ff0000",
+	}); err != nil {
+		t.Fatal(err)
+	}
+	info := findStyleInfo(t, "test-catalog")
+	assertEqual(t, true, info.Custom, "")
+	assertEqual(t, true, info.Dark, "")
+	assertEqual(t, "#ff0000", info.Palette[0], "")
+}
@@ -0,0 +1,104 @@
+package splash
+
+import (
+	"fmt"
+
+	"github.com/alecthomas/chroma/v2"
+	"github.com/alecthomas/chroma/v2/lexers"
+	"github.com/xyproto/splash/internal/classifier"
+)
+
+// DefaultMinConfidence is a minimum confidence for WithLanguageClassifier that
+// lets through most code, but not tiny snippets. Prose is recognized by the
+// classifier as "plaintext".
+const DefaultMinConfidence = 0.4
+
+// plainText is the lexer for code that the classifier is not sure about
+var plainText = lexers.Get("plaintext")
+
+// LanguageGuess is a language that code may be written in, together with how
+// likely it is, from 0 to 1
+type LanguageGuess struct {
+	Language   string  // the name of the chroma lexer, like "Go"
+	Confidence float64 // the confidences for all the languages that the classifier knows add up to 1
+}
+
+// WithLanguageClassifier makes the language of blocks without any language
+// hints be guessed by a classifier that is built into splash, instead of by
+// chroma and the default language. Only the given languages are considered,
+// like "go" and "yaml", or all the languages that the classifier knows if
+// none are given. Code in other languages then usually gets a low confidence.
+// If the best guess has a lower confidence than minConfidence, like
+// DefaultMinConfidence, the block is highlighted as plain text.
+func WithLanguageClassifier(minConfidence float64, languages ...string) Option {
+	return func(h *Highlighter) {
+		h.classify = true
+		h.minConfidence = minConfidence
+		h.candidates = languages
+	}
+}
+
+// ClassifyLanguage returns the languages that the source code may be written
+// in, the most likely first. If languages are given, like "go" and "yaml",
+// only those are returned, with the same confidences as without them. Prose is
+// returned as "plaintext". Nil is returned if the code has too little in
+// common with the languages that the classifier knows.
+func ClassifyLanguage(source string, languages ...string) []LanguageGuess {
+	return defaultHighlighter.Load().classifyLanguage(source, languages)
+}
+
+// ClassifierLanguages returns the names of the languages that the classifier
+// knows, including "plaintext" for prose
+func ClassifierLanguages() []string {
+	names := make([]string, 0, len(classifier.Default.Languages))
+	for _, info := range Languages() {
+		if _, ok := classifier.Default.Languages[info.Name]; ok {
+			names = append(names, info.Name)
+		}
+	}
+	return names
+}
+
+// classifyLanguage returns the ranked guesses for the source code, with the
+// language names resolved to chroma lexer names
+func (h *Highlighter) classifyLanguage(source string, languages []string) []LanguageGuess {
+	var candidates []string
+	for _, language := range languages {
+		if lexer := h.lexerByName(language); lexer != nil {
+			candidates = append(candidates, lexer.Config().Name)
+		}
+	}
+	if len(languages) > 0 && len(candidates) == 0 {
+		return nil
+	}
+	guesses := classifier.Default.Classify(source, candidates)
+	if guesses == nil {
+		return nil
+	}
+	result := make([]LanguageGuess, len(guesses))
+	for i, guess := range guesses {
+		result[i] = LanguageGuess{Language: guess.Language, Confidence: guess.Confidence}
+	}
+	return result
+}
+
+// lexerFromClassifier returns the lexer for the best guess of the classifier,
+// or the plain text lexer if the guess is not good enough
+func (h *Highlighter) lexerFromClassifier(source string) (chroma.Lexer, LanguageChoice) {
+	guesses := h.classifyLanguage(source, h.candidates)
+	if len(guesses) == 0 {
+		return plainText, LanguageChoice{Method: LanguageFromFallback, Reason: "the classifier found too little to go on"}
+	}
+	best := guesses[0]
+	if best.Confidence < h.minConfidence {
+		return plainText, LanguageChoice{Method: LanguageFromFallback, Hint: best.Language, Reason: fmt.Sprintf("the best guess %q has a confidence of %.2f, below %.2f", best.Language, best.Confidence, h.minConfidence)}
+	}
+	if best.Language == plainText.Config().Name {
+		return plainText, LanguageChoice{Method: LanguageFromFallback, Hint: best.Language, Reason: fmt.Sprintf("the classifier found prose, with a confidence of %.2f", best.Confidence)}
+	}
+	lexer := lexers.Get(best.Language)
+	if lexer == nil {
+		return plainText, LanguageChoice{Method: LanguageFromFallback, Hint: best.Language, Reason: fmt.Sprintf("the guessed language %q is unknown", best.Language)}
+	}
+	return lexer, LanguageChoice{Method: LanguageFromClassifier, Hint: best.Language, Reason: fmt.Sprintf("the classifier has a confidence of %.2f", best.Confidence)}
+}
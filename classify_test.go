@@ -0,0 +1,70 @@
+package splash
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestClassifyLanguage(t *testing.T) {
+	guesses := ClassifyLanguage("package main\n\nfunc main() {\n\tfmt.Println(\"hi\")\n}\n")
+	if len(guesses) == 0 || guesses[0].Language != "Go" {
+		t.Fatalf("expected Go first, got %v", guesses)
+	}
+	for i := 1; i < len(guesses); i++ {
+		if guesses[i].Confidence > guesses[i-1].Confidence {
+			t.Fatalf("expected the guesses to be ranked, got %v", guesses)
+		}
+	}
+	guesses = ClassifyLanguage("name: test\nitems:\n  - one\n", "json", "yml")
+	if len(guesses) != 2 || guesses[0].Language != "YAML" {
+		t.Errorf("expected YAML and JSON only, got %v", guesses)
+	}
+	guesses = ClassifyLanguage("The quick brown fox jumps over the lazy dog and then it went home to sleep.")
+	if len(guesses) == 0 || guesses[0].Language != "plaintext" {
+		t.Errorf("expected an English sentence to be classified as plain text, got %v", guesses)
+	}
+	if guesses := ClassifyLanguage("x := 1", "nonsense"); guesses != nil {
+		t.Errorf("expected no guesses for unknown candidates, got %v", guesses)
+	}
+	if languages := ClassifierLanguages(); len(languages) < 20 {
+		t.Errorf("expected the classifier to know at least 20 languages, got %v", languages)
+	}
+}
+
+func TestWithLanguageClassifier(t *testing.T) {
+	tests := []struct {
+		input  string
+		lexer  string
+		method LanguageMethod
+	}{
+		{"<pre>server:\n  port: 8080\n  hosts:\n    - one\n    - two\n</pre>", "YAML", LanguageFromClassifier},
+		{"<pre>func main() {\n\tx := 1\n\tif err != nil {\n\t\treturn\n\t}\n}</pre>", "Go", LanguageFromClassifier},
+		{"<pre>This is just some text.</pre>", "plaintext", LanguageFromFallback},
+		{"<pre>The quick brown fox jumps over the lazy dog and then it went home to sleep.</pre>", "plaintext", LanguageFromFallback},
+		{"<pre class=\"language-python\">x := 1</pre>", "Python", LanguageFromClass},
+	}
+	for _, test := range tests {
+		var choice LanguageChoice
+		h := New(WithLanguageClassifier(DefaultMinConfidence), WithLanguageHandler(func(c LanguageChoice) {
+			choice = c
+		}))
+		if _, _, err := h.Highlight([]byte(test.input)); err != nil {
+			t.Fatal(err)
+		}
+		if choice.Lexer != test.lexer || choice.Method != test.method {
+			t.Errorf("%s: expected %s from %s, got %+v", test.input, test.lexer, test.method, choice)
+		}
+	}
+
+	// With only Go and YAML as candidates, the Python code must be one of them or plain text
+	var choice LanguageChoice
+	h := New(WithLanguageClassifier(0.9, "go", "yaml"), WithLanguageHandler(func(c LanguageChoice) {
+		choice = c
+	}))
+	if _, _, err := h.Highlight([]byte("<pre>def greet(name):\n    print(name)\n    return None\n</pre>")); err != nil {
+		t.Fatal(err)
+	}
+	if choice.Method != LanguageFromFallback || !strings.Contains(choice.Reason, "below 0.90") {
+		t.Errorf("expected plain text below the minimum confidence, got %+v", choice)
+	}
+}
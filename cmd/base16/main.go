@@ -0,0 +1,58 @@
+// base16 converts a Base16 or Base24 color scheme in YAML to a chroma style in XML
+package main
+
+import (
+	"encoding/xml"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/xyproto/splash"
+)
+
+func main() {
+	output := flag.String("o", "", "output file, like \"tomorrow-night.xml\" (default is stdout)")
+	flag.Usage = func() {
+		fmt.Fprintln(os.Stderr, "Usage: base16 [-o style.xml] scheme.yaml")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+	if flag.NArg() != 1 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	f, err := os.Open(flag.Arg(0))
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	defer f.Close()
+
+	scheme, err := splash.ParseBase16(f)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%s: %v\n", flag.Arg(0), err)
+		os.Exit(1)
+	}
+	style, err := scheme.Style()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%s: %v\n", flag.Arg(0), err)
+		os.Exit(1)
+	}
+
+	xmlData, err := xml.MarshalIndent(style, "", "  ")
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	xmlData = append(xmlData, '\n')
+
+	if *output == "" {
+		os.Stdout.Write(xmlData)
+		return
+	}
+	if err := os.WriteFile(*output, xmlData, 0644); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
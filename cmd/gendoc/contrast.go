@@ -0,0 +1,86 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"html"
+	"os"
+
+	"github.com/xyproto/splash"
+)
+
+// sortScript makes the tables sortable by clicking on a column header
+const sortScript = `<script>
+document.querySelectorAll("th").forEach(function (th) {
+  th.addEventListener("click", function () {
+    var table = th.closest("table"), body = table.tBodies[0];
+    var index = Array.prototype.indexOf.call(th.parentNode.children, th);
+    var ascending = th.dataset.order !== "asc";
+    th.dataset.order = ascending ? "asc" : "desc";
+    Array.from(body.rows).sort(function (a, b) {
+      var x = a.cells[index].dataset.value || a.cells[index].textContent;
+      var y = b.cells[index].dataset.value || b.cells[index].textContent;
+      var result = isNaN(x) || isNaN(y) ? x.localeCompare(y) : x - y;
+      return ascending ? result : -result;
+    }).forEach(function (row) { body.appendChild(row); });
+  });
+});
+</script>`
+
+// styleReport is the contrast report for one of the styles in the gallery
+type styleReport struct {
+	*splash.ContrastReport
+	style splash.StyleInfo
+}
+
+// generateContrastReport writes an HTML page with the WCAG contrast ratios
+// for all the token types of all the styles
+func generateContrastReport(filename string) {
+	reports := make([]styleReport, 0, len(styles))
+	for _, style := range styles {
+		// AuditStyles leaves out styles that can not be found, so keep each report together with its style
+		for _, report := range splash.AuditStyles(style.Name) {
+			reports = append(reports, styleReport{report, style})
+		}
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("<!doctype html><html><head><title>")
+	buf.WriteString(title + " - Contrast")
+	buf.WriteString("</title><style>")
+	buf.WriteString(simpleCSS + " table { border-collapse: collapse; margin-bottom: 3em; } th { cursor: pointer; text-align: left; } th, td { padding: 0.2em 1em; border-bottom: 1px solid #8a7f74; } .fail { color: #b00000; font-weight: bold; } .swatch { font-family: monospace; padding: 0.2em 0.5em; }")
+	buf.WriteString("</style></head><body><h1 id='main-headline'>")
+	buf.WriteString("Contrast")
+	buf.WriteString("</h1>")
+	fmt.Fprintf(&buf, "<p>The WCAG 2 contrast ratio between the text and the background, for every token type of every style. Level AA requires %.1f and level AAA requires %.1f for normal text. Click a column header to sort.</p>", splash.ContrastAA, splash.ContrastAAA)
+
+	// One row per style
+	buf.WriteString("<h2>Styles</h2><table><thead><tr><th>Style</th><th>Lowest ratio</th><th>Below AA</th><th>Below AAA</th><th>Token types</th></tr></thead><tbody>")
+	for _, report := range reports {
+		fmt.Fprintf(&buf, "<tr><td><a href='%s.html'>%s</a></td><td data-value='%.2f'>%.2f</td><td>%d</td><td>%d</td><td>%d</td></tr>",
+			report.style.FileName, html.EscapeString(report.Style), report.MinRatio(), report.MinRatio(),
+			len(report.Failures(splash.ContrastAA)), len(report.Failures(splash.ContrastAAA)), len(report.Tokens))
+	}
+	buf.WriteString("</tbody></table>")
+
+	// One row per token type that does not pass AAA
+	buf.WriteString("<h2>Token types below AAA</h2><table><thead><tr><th>Style</th><th>Token type</th><th>Sample</th><th>Ratio</th><th>AA</th><th>AAA</th></tr></thead><tbody>")
+	for _, report := range reports {
+		for _, t := range report.Failures(splash.ContrastAAA) {
+			aa := "pass"
+			if !t.PassesAA() {
+				aa = "<span class='fail'>fail</span>"
+			}
+			fmt.Fprintf(&buf, "<tr><td>%s</td><td>%s</td><td><span class='swatch' style='color: %s; background-color: %s'>%s on %s</span></td><td data-value='%.2f'>%.2f</td><td>%s</td><td><span class='fail'>fail</span></td></tr>",
+				html.EscapeString(report.Style), t.TokenType, t.Foreground, t.Background, t.Foreground, t.Background, t.Ratio, t.Ratio, aa)
+		}
+	}
+	buf.WriteString("</tbody></table>")
+	buf.WriteString("<p><a href='index.html' alt='Back to overview'>Back to overview</a></p>")
+	buf.WriteString(sortScript)
+	buf.WriteString(footer())
+
+	if err := os.WriteFile(filename, buf.Bytes(), 0644); err != nil {
+		panic(err)
+	}
+}
@@ -2,6 +2,7 @@ package main
 
 import (
 	"bytes"
+	"flag"
 	"fmt"
 	"os"
 	"time"
@@ -27,6 +28,13 @@ func main() {
 </pre></code>`
 )
 
+// styles are the styles in the gallery, including any custom styles
+var styles []splash.StyleInfo
+
+// options are the splash options given on the command line. Strict style
+// lookup makes sure that no page is generated with the fallback style.
+var options = []splash.Option{splash.WithStrictStyles(true)}
+
 func footer() string {
 	var buf bytes.Buffer
 	buf.WriteString("<small>Generated ")
@@ -44,19 +52,19 @@ func generateGallery(sampleContent, dirname string) {
 		// Generate a HTML document for the current style name
 		var inputBuffer bytes.Buffer
 		inputBuffer.WriteString("<!doctype html><html><head><title>")
-		inputBuffer.WriteString(style.styleName)
+		inputBuffer.WriteString(style.Name)
 		inputBuffer.WriteString("</title><style>")
 		inputBuffer.WriteString(simpleCSS + " a { text-decoration: none; }  a:hover { color: #4682B4; }")
 		inputBuffer.WriteString("</style></head><body>")
 		inputBuffer.WriteString("<h1>")
-		inputBuffer.WriteString("<a alt='View " + style.styleName + " on a page with all the styles' href='all.html#" + style.fileName + "'>" + style.styleName + "</a>")
+		inputBuffer.WriteString("<a alt='View " + style.Name + " on a page with all the styles' href='all.html#" + style.FileName + "'>" + style.Name + "</a>")
 		inputBuffer.WriteString("</h1>")
 		inputBuffer.WriteString(sampleContent)
 
 		// Button to the previous style, if possible
 		if i > 0 {
 			prevStyle := styles[i-1]
-			inputBuffer.WriteString("<button onClick=\"location.href='" + prevStyle.fileName + ".html'\">Prev</button>")
+			inputBuffer.WriteString("<button onClick=\"location.href='" + prevStyle.FileName + ".html'\">Prev</button>")
 		} else {
 			inputBuffer.WriteString("<button disabled='true'>Prev</button>")
 		}
@@ -64,7 +72,7 @@ func generateGallery(sampleContent, dirname string) {
 		// Button to the next style, if possible
 		if i < (len(styles) - 1) {
 			nextStyle := styles[i+1]
-			inputBuffer.WriteString("<button onClick=\"location.href='" + nextStyle.fileName + ".html'\">Next</button>")
+			inputBuffer.WriteString("<button onClick=\"location.href='" + nextStyle.FileName + ".html'\">Next</button>")
 		} else {
 			inputBuffer.WriteString("<button disabled='true'>Next</button>")
 		}
@@ -78,13 +86,13 @@ func generateGallery(sampleContent, dirname string) {
 		inputBuffer.WriteString("</body></html>")
 
 		// Highlight the source code in the HTML with the current style
-		htmlBytes, err := splash.Splash(inputBuffer.Bytes(), style.styleName)
+		htmlBytes, err := splash.New(append(options, splash.WithStyle(style.Name))...).Splash(inputBuffer.Bytes())
 		if err != nil {
 			panic(err)
 		}
 
 		// Write the HTML sample using the XML filename
-		err = os.WriteFile(dirname+"/"+style.fileName+".html", htmlBytes, 0644)
+		err = os.WriteFile(dirname+"/"+style.FileName+".html", htmlBytes, 0644)
 		if err != nil {
 			panic(err)
 		}
@@ -101,11 +109,12 @@ func generateGallery(sampleContent, dirname string) {
 	buf.WriteString(title)
 	buf.WriteString("</h1><ul>")
 	for _, style := range styles {
-		buf.WriteString("<li><a href=\"" + style.fileName + ".html\" alt=\"" + style.styleName + " style\">" + style.styleName + "</a></li>")
+		buf.WriteString("<li><a href=\"" + style.FileName + ".html\" alt=\"" + style.Name + " style\">" + style.Name + "</a></li>")
 	}
 	buf.WriteString("</ul>")
 	buf.WriteString("<p><a href='all.html' alt='All styles on one page'>All styles on one page</a></p>")
 	if dirname == "." {
+		buf.WriteString("<p><a href='contrast.html' alt='Contrast of all styles'>Contrast of all styles</a></p>")
 		buf.WriteString("<p><a href='longer/index.html' alt='Gallery with longer code samples'>Gallery with longer code samples</a></p>")
 	} else {
 		buf.WriteString("<p><a href='../index.html' alt='Gallery with shorter code samples'>Gallery with shorter code samples</a></p>")
@@ -127,31 +136,16 @@ func generateGallery(sampleContent, dirname string) {
 	buf.WriteString(title)
 	buf.WriteString("</h1>")
 	for _, style := range styles {
-		buf.WriteString("<a name='" + style.fileName + "'>") // HTML anchor
+		buf.WriteString("<a name='" + style.FileName + "'>") // HTML anchor
 		buf.WriteString("<h2>")
-		buf.WriteString("<a id='stylelink' href='" + style.fileName + ".html' alt='View only " + style.styleName + "'>" + style.styleName + "</a>")
+		buf.WriteString("<a id='stylelink' href='" + style.FileName + ".html' alt='View only " + style.Name + "'>" + style.Name + "</a>")
 		buf.WriteString("</h2>")
 
-		htmlBytes, cssBytes, err := splash.Highlight([]byte(sampleContent), style.styleName, false)
+		// Use inline styles, since all the styles are on the same page
+		htmlBytes, _, err := splash.New(append(options, splash.WithStyle(style.Name), splash.WithInlineStyles(true))...).Highlight([]byte(sampleContent))
 		if err != nil {
 			panic(err)
 		}
-		cssElements := bytes.Split(cssBytes, []byte("}"))
-		for _, element := range cssElements {
-			elements := bytes.Split(bytes.Replace(element, []byte(".chroma"), []byte(""), -1), []byte("{"))
-			if len(elements) == 2 {
-				className := bytes.TrimSpace(elements[0])
-				if bytes.HasPrefix(className, []byte(".")) {
-					className = className[1:]
-				}
-				style := bytes.TrimSpace(elements[1])
-				if len(className) == 0 {
-					htmlBytes = bytes.Replace(htmlBytes, []byte("<pre "), []byte("<pre style=\""+string(style)+"\" "), -1)
-				} else {
-					htmlBytes = bytes.Replace(htmlBytes, []byte("<span class=\""+string(className)+"\""), []byte("<span style=\""+string(style)+"\""), -1)
-				}
-			}
-		}
 		buf.WriteString("</a>") // HTML anchor
 		buf.Write(htmlBytes)
 	}
@@ -170,6 +164,27 @@ func generateGallery(sampleContent, dirname string) {
 }
 
 func main() {
+	classPrefix := flag.String("prefix", "", "CSS class prefix, like \"splash-\"")
+	scopeSelector := flag.String("scope", "", "CSS selector that all CSS rules are placed under, like \"#article .code\"")
+	stylesDir := flag.String("styles", "", "directory with custom styles, as chroma .xml or .json files or Base16 .yaml or .yml schemes, to add to the gallery")
+	flag.Parse()
+
+	// Register the custom styles before changing directory, since the path may be relative
+	if *stylesDir != "" {
+		if _, err := splash.RegisterStyleDir(*stylesDir); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+	}
+	styles = splash.Styles()
+
+	if *classPrefix != "" {
+		options = append(options, splash.WithClassPrefix(*classPrefix))
+	}
+	if *scopeSelector != "" {
+		options = append(options, splash.WithScopeSelector(*scopeSelector))
+	}
+
 	// Try to change directory to "docs" and if that does not work,
 	// try to change directory to ../../docs
 	if err := os.Chdir("docs"); err != nil {
@@ -179,6 +194,7 @@ func main() {
 		}
 	}
 	generateGallery(sampleContent, ".")
+	generateContrastReport("contrast.html")
 	// TODO: Create directory first
 	generateGallery(longerSampleContent, "longer")
 }
@@ -15,7 +15,7 @@ import (
 )
 
 const (
-	stylesSourceFile = "cmd/gendoc/styles.go"
+	stylesSourceFile = "stylefiles.go"
 	chromaRepoURL    = "https://github.com/alecthomas/chroma.git"
 	chromaTempDir    = "/tmp/chroma-latest"
 	chromaStylesPath = "/tmp/chroma-latest/styles"
@@ -51,16 +51,16 @@ func fetchLatestChromaStyles() error {
 
 func generateGoSourceCode(styles []styleInfo) string {
 	var buffer bytes.Buffer
-	buffer.WriteString("package main\n\n")
+	buffer.WriteString("package splash\n\n")
 	buffer.WriteString("// This file is auto-generated by cmd/selfupdate\n")
 	buffer.WriteString("// It maps XML filenames to the actual style names registered in chroma\n\n")
 
-	buffer.WriteString("type styleMapping struct {\n")
+	buffer.WriteString("type styleFile struct {\n")
 	buffer.WriteString("\tfileName  string // XML filename without .xml extension\n")
 	buffer.WriteString("\tstyleName string // Style name as defined in the XML\n")
 	buffer.WriteString("}\n\n")
 
-	buffer.WriteString("var styles = []styleMapping{\n")
+	buffer.WriteString("var styleFiles = []styleFile{\n")
 	for _, style := range styles {
 		buffer.WriteString(fmt.Sprintf("\t{fileName: \"%s\", styleName: \"%s\"},\n", style.fileName, style.styleName))
 	}
@@ -1,20 +1,32 @@
 package main
 
 import (
+	"flag"
 	"os"
 
 	"github.com/xyproto/splash"
 )
 
 func main() {
+	styleName := flag.String("style", "monokai", "syntax highlighting style")
+	stylesDir := flag.String("styles", "", "directory with custom styles, as chroma .xml or .json files or Base16 .yaml or .yml schemes")
+	flag.Parse()
+
+	// Register the custom styles, so that they can be selected with -style
+	if *stylesDir != "" {
+		if _, err := splash.RegisterStyleDir(*stylesDir); err != nil {
+			panic(err)
+		}
+	}
+
 	// Read "input.html"
 	inputHTML, err := os.ReadFile("input.html")
 	if err != nil {
 		panic(err)
 	}
 
-	// Highlight the source code in the HTML document with the monokai style
-	outputHTML, err := splash.Splash(inputHTML, "monokai")
+	// Highlight the source code in the HTML document with the selected style
+	outputHTML, err := splash.Splash(inputHTML, *styleName)
 	if err != nil {
 		panic(err)
 	}
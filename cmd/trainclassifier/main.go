@@ -0,0 +1,93 @@
+// trainclassifier trains the language classifier on the sample files and
+// writes the model as Go source code. Run it from the root of the repository.
+// The samples are in one directory per language, like "internal/classifier/testdata/go".
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/format"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/alecthomas/chroma/v2/lexers"
+	"github.com/xyproto/splash/internal/classifier"
+)
+
+func generateGoSourceCode(model *classifier.Model) ([]byte, error) {
+	var buffer bytes.Buffer
+	buffer.WriteString("package classifier\n\n")
+	buffer.WriteString("// This file is auto-generated by cmd/trainclassifier\n")
+	buffer.WriteString("// It holds the token counts for the samples in internal/classifier/testdata\n\n")
+
+	languages := make([]string, 0, len(model.Languages))
+	for language := range model.Languages {
+		languages = append(languages, language)
+	}
+	sort.Strings(languages)
+
+	fmt.Fprintf(&buffer, "// Default is the model that is trained on the samples\n")
+	fmt.Fprintf(&buffer, "var Default = &Model{\n\tVocabulary: %d,\n\tLanguages: map[string]Language{\n", model.Vocabulary)
+	for _, language := range languages {
+		counts := model.Languages[language]
+		tokens := make([]string, 0, len(counts.Tokens))
+		for token := range counts.Tokens {
+			tokens = append(tokens, token)
+		}
+		sort.Strings(tokens)
+		fmt.Fprintf(&buffer, "\t\t%q: {Total: %d, Tokens: map[string]int{", language, counts.Total)
+		for _, token := range tokens {
+			fmt.Fprintf(&buffer, "%q: %d, ", token, counts.Tokens[token])
+		}
+		buffer.WriteString("}},\n")
+	}
+	buffer.WriteString("\t},\n}\n")
+	return format.Source(buffer.Bytes())
+}
+
+func main() {
+	samplesDir := flag.String("samples", "internal/classifier/testdata", "directory with sample files")
+	output := flag.String("o", "internal/classifier/model.go", "output file")
+	keep := flag.Int("keep", 150, "the number of tokens to keep per language")
+	flag.Parse()
+
+	entries, err := os.ReadDir(*samplesDir)
+	if err != nil {
+		log.Fatalf("error reading samples: %v", err)
+	}
+	samples := make(map[string][]string)
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		lexer := lexers.Get(entry.Name())
+		if lexer == nil {
+			log.Printf("Warning: no language for %s\n", entry.Name())
+			continue
+		}
+		language := lexer.Config().Name
+		files, err := filepath.Glob(filepath.Join(*samplesDir, entry.Name(), "*"))
+		if err != nil {
+			log.Fatalf("error reading samples: %v", err)
+		}
+		for _, file := range files {
+			data, err := os.ReadFile(file)
+			if err != nil {
+				log.Fatalf("error reading sample: %v", err)
+			}
+			samples[language] = append(samples[language], string(data))
+		}
+	}
+
+	sourceCode, err := generateGoSourceCode(classifier.Train(samples, *keep))
+	if err != nil {
+		log.Fatalf("error formatting source code: %v", err)
+	}
+	if err := os.WriteFile(*output, sourceCode, 0644); err != nil {
+		log.Fatalf("error writing source code to file: %v", err)
+	}
+	log.Printf("Trained on %d languages\n", len(samples))
+}
@@ -0,0 +1,74 @@
+package splash
+
+import (
+	"context"
+	"io"
+
+	"github.com/alecthomas/chroma/v2"
+)
+
+// HighlightContext does the same as Highlight, but stops and returns
+// ctx.Err() if the context is cancelled or its deadline passes. The context
+// is checked between the blocks of code, and while the code is tokenised.
+func HighlightContext(ctx context.Context, htmlData []byte, styleName string, unescape bool) ([]byte, []byte, error) {
+	return defaultHighlighter.Load().with(WithStyle(styleName), WithUnescape(unescape)).HighlightContext(ctx, htmlData)
+}
+
+// SplashContext does the same as Splash, but stops and returns ctx.Err() if
+// the context is cancelled or its deadline passes.
+func SplashContext(ctx context.Context, htmlData []byte, styleName string) ([]byte, error) {
+	return defaultHighlighter.Load().with(WithStyle(styleName)).SplashContext(ctx, htmlData)
+}
+
+// HighlightContext does the same as Highlight, but stops and returns
+// ctx.Err() if the context is cancelled or its deadline passes. The context
+// is checked between the blocks of code, and while the code is tokenised.
+func (h *Highlighter) HighlightContext(ctx context.Context, htmlData []byte) ([]byte, []byte, error) {
+	return h.highlight(ctx, htmlData, nil)
+}
+
+// SplashContext does the same as Splash, but stops and returns ctx.Err() if
+// the context is cancelled or its deadline passes.
+func (h *Highlighter) SplashContext(ctx context.Context, htmlData []byte) ([]byte, error) {
+	return h.splash(ctx, htmlData)
+}
+
+// SplashStreamContext does the same as SplashStream, but stops and returns
+// ctx.Err() if the context is cancelled or its deadline passes. The HTML that
+// has been written to w by then ends where the streaming stopped.
+func (h *Highlighter) SplashStreamContext(ctx context.Context, w io.Writer, r io.Reader) error {
+	return h.splashStream(ctx, w, r)
+}
+
+// cancellableLexer is a lexer interceptor that stops returning tokens when
+// the context is done. Like tokeniseLimiter, it must be applied before
+// chroma.Coalesce.
+type cancellableLexer struct {
+	chroma.Lexer
+	done <-chan struct{}
+}
+
+func (l *cancellableLexer) Tokenise(options *chroma.TokeniseOptions, text string) (chroma.Iterator, error) {
+	it, err := l.Lexer.Tokenise(options, text)
+	if err != nil {
+		return nil, err
+	}
+	return func() chroma.Token {
+		select {
+		case <-l.done:
+			return chroma.EOF
+		default:
+			return it()
+		}
+	}, nil
+}
+
+// cancellable returns a lexer that stops when the context is done, or the
+// given lexer if the context can not be cancelled
+func cancellable(ctx context.Context, lexer chroma.Lexer) chroma.Lexer {
+	done := ctx.Done()
+	if done == nil {
+		return lexer
+	}
+	return &cancellableLexer{Lexer: lexer, done: done}
+}
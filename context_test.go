@@ -0,0 +1,58 @@
+package splash
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestHighlightContext(t *testing.T) {
+	input := []byte("<pre class=\"language-go\">x := 1</pre>")
+	htmlData, cssData, err := HighlightContext(context.Background(), input, "monokai", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expectedHTML, expectedCSS, err := Highlight(input, "monokai", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(htmlData, expectedHTML) || !bytes.Equal(cssData, expectedCSS) {
+		t.Error("expected the same result as Highlight")
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, _, err := HighlightContext(ctx, input, "monokai", false); !errors.Is(err, context.Canceled) {
+		t.Errorf("expected context.Canceled, got %v", err)
+	}
+	if _, err := SplashContext(ctx, []byte("<html>"+string(input)+"</html>"), "monokai"); !errors.Is(err, context.Canceled) {
+		t.Errorf("expected context.Canceled, got %v", err)
+	}
+}
+
+func TestContextDeadline(t *testing.T) {
+	// The slow lexer never stops by itself, so this only returns if the deadline is checked while tokenising
+	input := "<html><pre class=\"language-splash-slow\">x</pre><pre class=\"language-go\">x := 1</pre></html>"
+	for _, h := range []*Highlighter{New(), New(WithErrorIsolation(true))} {
+		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+		start := time.Now()
+		_, _, err := h.HighlightContext(ctx, []byte(input))
+		cancel()
+		if !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("expected context.DeadlineExceeded, got %v", err)
+		}
+		if elapsed := time.Since(start); elapsed > time.Second {
+			t.Errorf("expected highlighting to stop at the deadline, it took %v", elapsed)
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	var buf bytes.Buffer
+	if err := New().SplashStreamContext(ctx, &buf, strings.NewReader(input)); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected context.DeadlineExceeded from SplashStreamContext, got %v", err)
+	}
+}
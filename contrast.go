@@ -0,0 +1,220 @@
+package splash
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"sync"
+
+	"github.com/alecthomas/chroma/v2"
+)
+
+const (
+	// ContrastAA is the minimum contrast ratio for normal text in WCAG 2, level AA
+	ContrastAA = 4.5
+	// ContrastAAA is the minimum contrast ratio for normal text in WCAG 2, level AAA
+	ContrastAAA = 7.0
+)
+
+// ContrastMode is what to do with styles that have too little contrast
+type ContrastMode int
+
+const (
+	// RefuseLowContrast makes highlighting fail with a *ContrastError
+	RefuseLowContrast ContrastMode = iota
+	// AdjustLowContrast makes the colors with too little contrast lighter or
+	// darker, until they have enough contrast
+	AdjustLowContrast
+)
+
+// WithMinContrast sets the minimum contrast ratio between the text and the
+// background, like ContrastAA, for all token types of the styles that are
+// used. Styles with less contrast are either refused or adjusted.
+func WithMinContrast(ratio float64, mode ContrastMode) Option {
+	return func(h *Highlighter) {
+		h.minContrast = ratio
+		h.contrastMode = mode
+	}
+}
+
+// TokenContrast is the contrast for one token type of a style
+type TokenContrast struct {
+	TokenType  chroma.TokenType
+	Foreground chroma.Colour
+	Background chroma.Colour
+	Ratio      float64
+}
+
+// PassesAA returns true if the contrast is enough for WCAG 2, level AA
+func (t TokenContrast) PassesAA() bool {
+	return t.Ratio >= ContrastAA
+}
+
+// PassesAAA returns true if the contrast is enough for WCAG 2, level AAA
+func (t TokenContrast) PassesAAA() bool {
+	return t.Ratio >= ContrastAAA
+}
+
+// ContrastReport is the result of checking the contrast of a style
+type ContrastReport struct {
+	Style  string          // the name of the style
+	Tokens []TokenContrast // the contrast per token type, sorted by token type
+}
+
+// Failures returns the token types with a contrast ratio below the given ratio
+func (r *ContrastReport) Failures(minRatio float64) []TokenContrast {
+	var failures []TokenContrast
+	for _, t := range r.Tokens {
+		if t.Ratio < minRatio {
+			failures = append(failures, t)
+		}
+	}
+	return failures
+}
+
+// MinRatio returns the lowest contrast ratio of all the token types
+func (r *ContrastReport) MinRatio() float64 {
+	minRatio := 21.0
+	for _, t := range r.Tokens {
+		minRatio = min(minRatio, t.Ratio)
+	}
+	return minRatio
+}
+
+// ContrastError is returned when a style has too little contrast
+type ContrastError struct {
+	Style    string          // the name of the style
+	MinRatio float64         // the minimum contrast ratio that was required
+	Failures []TokenContrast // the token types with too little contrast
+}
+
+func (e *ContrastError) Error() string {
+	worst := e.Failures[0]
+	for _, t := range e.Failures {
+		if t.Ratio < worst.Ratio {
+			worst = t
+		}
+	}
+	return fmt.Sprintf("style %q has %d token types with a contrast ratio below %.1f, the lowest is %s with %.2f", e.Style, len(e.Failures), e.MinRatio, worst.TokenType, worst.Ratio)
+}
+
+// relativeLuminance returns the relative luminance of a color, as defined by WCAG 2
+func relativeLuminance(c chroma.Colour) float64 {
+	channel := func(v uint8) float64 {
+		s := float64(v) / 255
+		if s <= 0.04045 {
+			return s / 12.92
+		}
+		return math.Pow((s+0.055)/1.055, 2.4)
+	}
+	return 0.2126*channel(c.Red()) + 0.7152*channel(c.Green()) + 0.0722*channel(c.Blue())
+}
+
+// ContrastRatio returns the WCAG 2 contrast ratio between two colors, from 1 to 21
+func ContrastRatio(a, b chroma.Colour) float64 {
+	la, lb := relativeLuminance(a), relativeLuminance(b)
+	if la < lb {
+		la, lb = lb, la
+	}
+	return (la + 0.05) / (lb + 0.05)
+}
+
+var (
+	black = chroma.MustParseColour("#000000")
+	white = chroma.MustParseColour("#ffffff")
+)
+
+// AuditStyle checks the contrast between the text color and the background
+// color of every token type of the given style. Colors that are not set are
+// taken to be the browser defaults, black text on a white background.
+func AuditStyle(style *chroma.Style) *ContrastReport {
+	report := &ContrastReport{Style: style.Name}
+	for _, tokenType := range style.Types() {
+		entry := style.Get(tokenType)
+		fg, bg := entry.Colour, entry.Background
+		if !fg.IsSet() {
+			fg = black
+		}
+		if !bg.IsSet() {
+			bg = white
+		}
+		report.Tokens = append(report.Tokens, TokenContrast{TokenType: tokenType, Foreground: fg, Background: bg, Ratio: ContrastRatio(fg, bg)})
+	}
+	sort.Slice(report.Tokens, func(i, j int) bool { return report.Tokens[i].TokenType < report.Tokens[j].TokenType })
+	return report
+}
+
+// AuditStyles checks the contrast of the styles with the given names
+func AuditStyles(styleNames ...string) []*ContrastReport {
+	reports := make([]*ContrastReport, 0, len(styleNames))
+	for _, styleName := range styleNames {
+		report := AuditStyle(getStyle(styleName))
+		report.Style = styleName
+		reports = append(reports, report)
+	}
+	return reports
+}
+
+// adjustedStyleKey identifies a style that has been adjusted for a minimum contrast ratio
+type adjustedStyleKey struct {
+	style    *chroma.Style
+	minRatio float64
+}
+
+// adjustedStyles holds the adjusted styles, so that the same style is
+// returned every time, and the CSS for it can be cached
+var adjustedStyles sync.Map // adjustedStyleKey -> *chroma.Style
+
+// checkContrast returns the given style if it has enough contrast, and
+// otherwise either a *ContrastError or an adjusted style
+func (h *Highlighter) checkContrast(style *chroma.Style) (*chroma.Style, error) {
+	failures := AuditStyle(style).Failures(h.minContrast)
+	if len(failures) == 0 {
+		return style, nil
+	}
+	if h.contrastMode == RefuseLowContrast {
+		return nil, &ContrastError{Style: style.Name, MinRatio: h.minContrast, Failures: failures}
+	}
+	key := adjustedStyleKey{style, h.minContrast}
+	if adjusted, ok := adjustedStyles.Load(key); ok {
+		return adjusted.(*chroma.Style), nil
+	}
+	builder := style.Builder()
+	for _, t := range failures {
+		entry := style.Get(t.TokenType)
+		entry.Colour = adjustColour(t.Foreground, t.Background, h.minContrast)
+		builder.AddEntry(t.TokenType, entry)
+	}
+	adjusted, err := builder.Build()
+	if err != nil {
+		return nil, err
+	}
+	actual, _ := adjustedStyles.LoadOrStore(key, adjusted)
+	return actual.(*chroma.Style), nil
+}
+
+// adjustColour returns the color closest to fg that has at least the given
+// contrast ratio against bg, by blending it with black or white
+func adjustColour(fg, bg chroma.Colour, minRatio float64) chroma.Colour {
+	target := white
+	if ContrastRatio(black, bg) > ContrastRatio(white, bg) {
+		target = black
+	}
+	blend := func(t float64) chroma.Colour {
+		mix := func(a, b uint8) int {
+			return int(math.Round(float64(a) + (float64(b)-float64(a))*t))
+		}
+		return chroma.NewColour(uint8(mix(fg.Red(), target.Red())), uint8(mix(fg.Green(), target.Green())), uint8(mix(fg.Blue(), target.Blue())))
+	}
+	// Find the smallest amount of blending that gives enough contrast
+	low, high := 0.0, 1.0
+	for range 20 {
+		middle := (low + high) / 2
+		if ContrastRatio(blend(middle), bg) >= minRatio {
+			high = middle
+		} else {
+			low = middle
+		}
+	}
+	return blend(high)
+}
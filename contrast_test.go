@@ -0,0 +1,71 @@
+package splash
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/alecthomas/chroma/v2"
+)
+
+func TestContrastRatio(t *testing.T) {
+	assertEqual(t, 21.0, math.Round(ContrastRatio(black, white)*100)/100, "")
+	assertEqual(t, 1.0, ContrastRatio(white, white), "")
+	// #767676 on white is the classic example of just passing AA
+	assertEqual(t, 4.54, math.Round(ContrastRatio(chroma.MustParseColour("#767676"), white)*100)/100, "")
+}
+
+func lowContrastStyle(t *testing.T) *chroma.Style {
+	t.Helper()
+	style, err := chroma.NewStyle("low contrast", chroma.StyleEntries{
+		chroma.Background: "bg:#ffffff #000000",
+		chroma.Comment:    "#dddddd",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return style
+}
+
+func TestAuditStyle(t *testing.T) {
+	report := AuditStyle(lowContrastStyle(t))
+	failures := report.Failures(ContrastAA)
+	assertEqual(t, 1, len(failures), "expected only the comments to fail")
+	assertEqual(t, chroma.Comment, failures[0].TokenType, "")
+	if report.MinRatio() >= ContrastAA {
+		t.Errorf("expected the lowest ratio to be below AA, got %.2f", report.MinRatio())
+	}
+}
+
+func TestMinContrast(t *testing.T) {
+	if _, err := RegisterStyle("Test Low Contrast", map[chroma.TokenType]string{
+		chroma.Background: "bg:#ffffff #000000",
+		chroma.Comment:    "#dddddd",
+	}); err != nil {
+		t.Fatal(err)
+	}
+	input := []byte("<pre><code class=\"language-go\">// hi\nfunc main() {}</code></pre>")
+
+	_, _, err := New(WithStyle("test-low-contrast"), WithMinContrast(ContrastAA, RefuseLowContrast)).Highlight(input)
+	var contrastErr *ContrastError
+	if !errors.As(err, &contrastErr) {
+		t.Fatalf("expected a ContrastError, got %v", err)
+	}
+	assertEqual(t, chroma.Comment, contrastErr.Failures[0].TokenType, "")
+
+	h := New(WithStyle("test-low-contrast"), WithMinContrast(ContrastAA, AdjustLowContrast))
+	if _, _, err := h.Highlight(input); err != nil {
+		t.Fatal(err)
+	}
+	adjusted, err := h.style("test-low-contrast")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(AuditStyle(adjusted).Failures(ContrastAA)) != 0 {
+		t.Errorf("expected the adjusted style to pass AA: %v", AuditStyle(adjusted).Failures(ContrastAA))
+	}
+	again, _ := h.style("test-low-contrast")
+	if again != adjusted {
+		t.Error("expected the adjusted style to be reused")
+	}
+}
@@ -0,0 +1,129 @@
+package splash
+
+import (
+	"bytes"
+	"regexp"
+	"strings"
+)
+
+var (
+	// classAttrRegexp matches the class attributes in HTML generated by chroma
+	classAttrRegexp = regexp.MustCompile(`class="([^"]*)"`)
+
+	// cssCommentRegexp matches CSS comments and newlines
+	cssCommentRegexp = regexp.MustCompile(`(?s)/\*.*?\*/|\n`)
+)
+
+// collectClasses adds the CSS classes that are used in the given HTML to used
+func collectClasses(htmlData []byte, used map[string]bool) {
+	for _, match := range classAttrRegexp.FindAllSubmatch(htmlData, -1) {
+		for _, class := range strings.Fields(string(match[1])) {
+			used[class] = true
+		}
+	}
+}
+
+// cssRule is a single rule from the CSS generated by chroma
+type cssRule struct {
+	comment  string // like "Keyword", may be empty
+	selector string // like ".chroma .k"
+	body     string // like "color: #ff79c6"
+}
+
+// parseCSS splits the CSS generated by chroma into rules. chroma writes one
+// rule per line, like: /* Keyword */ .chroma .k { color: #ff79c6 }
+func parseCSS(cssData []byte) []cssRule {
+	var rules []cssRule
+	for _, line := range strings.Split(string(cssData), "\n") {
+		var rule cssRule
+		if strings.HasPrefix(line, "/*") {
+			comment, rest, found := strings.Cut(line[2:], "*/")
+			if !found {
+				continue
+			}
+			rule.comment = strings.TrimSpace(comment)
+			line = rest
+		}
+		selector, rest, found := strings.Cut(line, "{")
+		if !found {
+			continue
+		}
+		rule.selector = strings.TrimSpace(selector)
+		rule.body = strings.TrimSpace(strings.TrimSuffix(strings.TrimSpace(rest), "}"))
+		rules = append(rules, rule)
+	}
+	return rules
+}
+
+// writeCSS writes the given rules in the same format as chroma
+func writeCSS(rules []cssRule) []byte {
+	var buf bytes.Buffer
+	for _, rule := range rules {
+		if rule.comment != "" {
+			buf.WriteString("/* " + rule.comment + " */ ")
+		}
+		buf.WriteString(rule.selector + " { " + rule.body + " }\n")
+	}
+	return buf.Bytes()
+}
+
+// properties returns the names of the properties that the rule sets, like "color"
+func (rule cssRule) properties() []string {
+	var names []string
+	for _, d := range parseDeclarations(rule.body) {
+		names = append(names, d.property)
+	}
+	return names
+}
+
+// value returns the value that the rule sets for the given property, or ""
+func (rule cssRule) value(property string) string {
+	for _, d := range parseDeclarations(rule.body) {
+		if d.property == property {
+			return d.value
+		}
+	}
+	return ""
+}
+
+// class returns the class that the rule is for, like "k" for ".chroma .k"
+// or "lnt" for ".chroma .lnt:target". Returns an empty string for the rules
+// for the outer ".bg" and ".chroma" classes.
+func (rule cssRule) class() string {
+	fields := strings.Fields(rule.selector)
+	if len(fields) < 2 {
+		return ""
+	}
+	class := strings.TrimPrefix(fields[len(fields)-1], ".")
+	if i := strings.IndexByte(class, ':'); i != -1 {
+		class = class[:i]
+	}
+	return class
+}
+
+// pruneCSS removes the rules from the CSS generated by chroma that are for
+// CSS classes that are not in used. The rules for the outer ".bg" and
+// ".chroma" classes are always kept.
+func pruneCSS(cssData []byte, used map[string]bool) []byte {
+	var rules []cssRule
+	for _, rule := range parseCSS(cssData) {
+		if class := rule.class(); class == "" || used[class] {
+			rules = append(rules, rule)
+		}
+	}
+	return writeCSS(rules)
+}
+
+// prependSelector places all the given rules under the given selector
+func prependSelector(cssData []byte, selector string) []byte {
+	rules := parseCSS(cssData)
+	for i := range rules {
+		rules[i].selector = selector + " " + rules[i].selector
+	}
+	return writeCSS(rules)
+}
+
+// stripCSS removes comments and newlines from the given CSS
+func stripCSS(cssData []byte) []byte {
+	return cssCommentRegexp.ReplaceAll(cssData, []byte("$1"))
+}
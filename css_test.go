@@ -0,0 +1,76 @@
+package splash
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestCSSOncePerDocument(t *testing.T) {
+	block := "<pre><code class=\"language-go\">func main() {}</code></pre>"
+	_, oneCSS, err := Highlight([]byte(block), "monokai", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, manyCSS, err := Highlight([]byte(strings.Repeat(block, 40)), "monokai", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertEqual(t, string(oneCSS), string(manyCSS), "the CSS should not be repeated per block")
+
+	_, noCSS, err := Highlight([]byte("<p>no code</p>"), "monokai", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertEqual(t, 0, len(noCSS), "no CSS is needed when there is no code")
+}
+
+func TestPrunedCSS(t *testing.T) {
+	input := []byte("<pre><code class=\"language-go\">func main() {}</code></pre>")
+	_, fullCSS, err := New(WithStyle("monokai")).Highlight(input)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, prunedCSS, err := New(WithStyle("monokai"), WithPrunedCSS(true)).Highlight(input)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(prunedCSS) >= len(fullCSS) {
+		t.Errorf("expected the pruned CSS to be smaller: %d >= %d", len(prunedCSS), len(fullCSS))
+	}
+	for _, rule := range []string{".bg {", ".chroma {", ".chroma .kd {", ".chroma .nf {", ".chroma .line {"} {
+		if !bytes.Contains(prunedCSS, []byte(rule)) {
+			t.Errorf("expected the pruned CSS to contain %q: %s", rule, prunedCSS)
+		}
+	}
+	for _, rule := range []string{".chroma .gd {", ".chroma .err {"} {
+		if bytes.Contains(prunedCSS, []byte(rule)) {
+			t.Errorf("expected the pruned CSS to not contain %q: %s", rule, prunedCSS)
+		}
+	}
+}
+
+func TestClassPrefixAndScopeSelector(t *testing.T) {
+	input := []byte(`<pre data-style="github"><code class="language-go">func main() {}</code></pre><pre>x := 1</pre>`)
+	htmlBytes, cssBytes, err := New(WithStyle("monokai"), WithClassPrefix("splash-"), WithScopeSelector("#article .code")).Highlight(input)
+	if err != nil {
+		t.Fatal(err)
+	}
+	output := string(htmlBytes)
+	if !strings.Contains(output, `class="splash-chroma splash-style-github"`) || !strings.Contains(output, `<span class="splash-kd">func</span>`) {
+		t.Errorf("expected prefixed classes in the HTML: %s", output)
+	}
+	if strings.Contains(output, `class="chroma"`) || strings.Contains(output, `class="kd"`) {
+		t.Errorf("expected no classes without the prefix: %s", output)
+	}
+	for _, rule := range []string{"#article .code .splash-chroma {", "#article .code .splash-chroma .splash-kd {", "#article .code .splash-chroma.splash-style-github .splash-kd {"} {
+		if !bytes.Contains(cssBytes, []byte(rule)) {
+			t.Errorf("expected the CSS to contain %q: %s", rule, cssBytes)
+		}
+	}
+	for _, rule := range parseCSS(bytes.ReplaceAll(cssBytes, []byte("}"), []byte("}\n"))) {
+		if !strings.HasPrefix(rule.selector, "#article .code .splash-") {
+			t.Errorf("expected all rules to be scoped and prefixed: %s", rule.selector)
+		}
+	}
+}
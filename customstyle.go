@@ -0,0 +1,238 @@
+package splash
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/alecthomas/chroma/v2"
+)
+
+// StyleError is returned when a custom style is invalid
+type StyleError struct {
+	Source    string // the file the style was read from, if any
+	Style     string // the name of the style, if known
+	TokenType string // the token type of the invalid entry, if any
+	Entry     string // the invalid style entry, if any
+	Err       error  // what is wrong
+}
+
+func (e *StyleError) Error() string {
+	var sb strings.Builder
+	sb.WriteString("invalid style")
+	if e.Style != "" {
+		fmt.Fprintf(&sb, " %q", e.Style)
+	}
+	if e.Source != "" {
+		fmt.Fprintf(&sb, " in %s", e.Source)
+	}
+	if e.TokenType != "" {
+		fmt.Fprintf(&sb, ": entry %s=%q", e.TokenType, e.Entry)
+	}
+	sb.WriteString(": ")
+	sb.WriteString(e.Err.Error())
+	return sb.String()
+}
+
+func (e *StyleError) Unwrap() error {
+	return e.Err
+}
+
+// customStyles holds the styles that have been registered with splash.
+// They are looked up before the styles that are built into chroma.
+var customStyles = struct {
+	sync.RWMutex
+	byName map[string]*chroma.Style // lowercase name or alias -> style
+}{byName: make(map[string]*chroma.Style)}
+
+// lookupCustomStyle returns the registered style with the given name or alias, if any
+func lookupCustomStyle(name string) *chroma.Style {
+	customStyles.RLock()
+	defer customStyles.RUnlock()
+	return customStyles.byName[strings.ToLower(name)]
+}
+
+// registerStyle makes the style available by its name and the given aliases.
+// A style with the same name replaces the previous one.
+func registerStyle(style *chroma.Style, aliases ...string) {
+	customStyles.Lock()
+	defer customStyles.Unlock()
+	customStyles.byName[strings.ToLower(style.Name)] = style
+	for _, alias := range aliases {
+		if alias != "" {
+			customStyles.byName[strings.ToLower(alias)] = style
+		}
+	}
+}
+
+// styleEntry is an entry of a style definition, before it has been validated
+type styleEntry struct {
+	Type  string `xml:"type,attr"`
+	Style string `xml:"style,attr"`
+}
+
+// buildStyle validates the given style definition and creates a chroma style
+func buildStyle(source, name string, entries []styleEntry) (*chroma.Style, error) {
+	if strings.TrimSpace(name) == "" {
+		return nil, &StyleError{Source: source, Err: errors.New("the style has no name")}
+	}
+	seen := make(chroma.StyleEntries, len(entries))
+	for _, entry := range entries {
+		if entry.Type == "" {
+			return nil, &StyleError{Source: source, Style: name, Err: fmt.Errorf("an entry with the style %q has no token type", entry.Style)}
+		}
+		tokenType, err := chroma.TokenTypeString(entry.Type)
+		if err != nil {
+			return nil, &StyleError{Source: source, Style: name, TokenType: entry.Type, Entry: entry.Style, Err: errors.New("unknown token type")}
+		}
+		if _, duplicate := seen[tokenType]; duplicate {
+			return nil, &StyleError{Source: source, Style: name, TokenType: entry.Type, Entry: entry.Style, Err: errors.New("the token type is given more than once")}
+		}
+		if _, err := chroma.ParseStyleEntry(entry.Style); err != nil {
+			return nil, &StyleError{Source: source, Style: name, TokenType: entry.Type, Entry: entry.Style, Err: err}
+		}
+		seen[tokenType] = entry.Style
+	}
+	style, err := chroma.NewStyle(name, seen)
+	if err != nil {
+		return nil, &StyleError{Source: source, Style: name, Err: err}
+	}
+	return style, nil
+}
+
+// RegisterStyle registers a style with the given name, where the entries are
+// chroma style entries like "bold #ff0000 bg:#000000". The style can then be
+// used by name, like any style that is built into chroma.
+func RegisterStyle(name string, entries map[chroma.TokenType]string) (*chroma.Style, error) {
+	list := make([]styleEntry, 0, len(entries))
+	for tokenType, entry := range entries {
+		list = append(list, styleEntry{Type: tokenType.String(), Style: entry})
+	}
+	// Sort the entries, so that the first invalid one is always the one that is reported
+	sort.Slice(list, func(i, j int) bool { return list[i].Type < list[j].Type })
+	style, err := buildStyle("", name, list)
+	if err != nil {
+		return nil, err
+	}
+	registerStyle(style)
+	return style, nil
+}
+
+// parseStyleXML reads a style in the chroma XML format, like:
+//
+//	<style name="corporate"><entry type="Keyword" style="bold #0055aa"/></style>
+func parseStyleXML(r io.Reader, source string) (*chroma.Style, error) {
+	var definition struct {
+		XMLName xml.Name     `xml:"style"`
+		Name    string       `xml:"name,attr"`
+		Entries []styleEntry `xml:"entry"`
+	}
+	if err := xml.NewDecoder(r).Decode(&definition); err != nil {
+		return nil, &StyleError{Source: source, Err: err}
+	}
+	return buildStyle(source, definition.Name, definition.Entries)
+}
+
+// parseStyleJSON reads a style in JSON, like:
+//
+//	{"name": "corporate", "entries": {"Keyword": "bold #0055aa"}}
+func parseStyleJSON(r io.Reader, source string) (*chroma.Style, error) {
+	var definition struct {
+		Name    string            `json:"name"`
+		Entries map[string]string `json:"entries"`
+	}
+	decoder := json.NewDecoder(r)
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&definition); err != nil {
+		return nil, &StyleError{Source: source, Err: err}
+	}
+	entries := make([]styleEntry, 0, len(definition.Entries))
+	for tokenType, entry := range definition.Entries {
+		entries = append(entries, styleEntry{Type: tokenType, Style: entry})
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Type < entries[j].Type })
+	return buildStyle(source, definition.Name, entries)
+}
+
+// RegisterStyleXML reads and registers a style in the chroma XML format
+func RegisterStyleXML(r io.Reader) (*chroma.Style, error) {
+	style, err := parseStyleXML(r, "")
+	if err != nil {
+		return nil, err
+	}
+	registerStyle(style)
+	return style, nil
+}
+
+// RegisterStyleJSON reads and registers a style in JSON, like
+// {"name": "corporate", "entries": {"Keyword": "bold #0055aa"}}
+func RegisterStyleJSON(r io.Reader) (*chroma.Style, error) {
+	style, err := parseStyleJSON(r, "")
+	if err != nil {
+		return nil, err
+	}
+	registerStyle(style)
+	return style, nil
+}
+
+// RegisterStyleFile reads and registers a style from a .xml file in the chroma
+// XML format, from a .json file, or from a Base16 or Base24 scheme in a .yaml
+// or .yml file. The style can be used both by its name and by the filename
+// without the extension, like "corporate" for "corporate.xml".
+func RegisterStyleFile(path string) (*chroma.Style, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	var style *chroma.Style
+	switch ext := strings.ToLower(filepath.Ext(path)); ext {
+	case ".xml":
+		style, err = parseStyleXML(f, path)
+	case ".json":
+		style, err = parseStyleJSON(f, path)
+	case ".yaml", ".yml":
+		style, err = parseBase16File(f, path)
+	default:
+		return nil, &StyleError{Source: path, Err: fmt.Errorf("unsupported file extension %q, expected .xml, .json, .yaml or .yml", ext)}
+	}
+	if err != nil {
+		return nil, err
+	}
+	registerStyle(style, strings.TrimSuffix(filepath.Base(path), filepath.Ext(path)))
+	return style, nil
+}
+
+// RegisterStyleDir registers all .xml, .json, .yaml and .yml styles in the
+// given directory, in alphabetical order. Files that can not be registered are reported
+// together in the returned error, while the other styles are still registered.
+func RegisterStyleDir(dir string) ([]*chroma.Style, error) {
+	files, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	var (
+		registered []*chroma.Style
+		errs       []error
+	)
+	for _, file := range files {
+		ext := strings.ToLower(filepath.Ext(file.Name()))
+		if file.IsDir() || (ext != ".xml" && ext != ".json" && ext != ".yaml" && ext != ".yml") {
+			continue
+		}
+		style, err := RegisterStyleFile(filepath.Join(dir, file.Name()))
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		registered = append(registered, style)
+	}
+	return registered, errors.Join(errs...)
+}
@@ -0,0 +1,92 @@
+package splash
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/alecthomas/chroma/v2"
+)
+
+func TestRegisterStyle(t *testing.T) {
+	style, err := RegisterStyle("Test Corporate", map[chroma.TokenType]string{
+		chroma.Background: "bg:#101010 #eeeeee",
+		chroma.Keyword:    "bold #0055aa",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if getStyle("Test Corporate") != style || getStyle("test-corporate") != style {
+		t.Error("expected getStyle to find the registered style")
+	}
+
+	_, cssBytes, err := New(WithStyle("test-corporate")).Highlight([]byte("<pre><code class=\"language-go\">func main() {}</code></pre>"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(cssBytes), "#0055aa") {
+		t.Errorf("expected the color of the registered style in the CSS: %s", cssBytes)
+	}
+}
+
+func TestRegisterStyleErrors(t *testing.T) {
+	var styleErr *StyleError
+
+	_, err := RegisterStyle("", map[chroma.TokenType]string{chroma.Keyword: "#ffffff"})
+	if !errors.As(err, &styleErr) {
+		t.Fatalf("expected a StyleError for a missing name, got %v", err)
+	}
+
+	_, err = RegisterStyle("broken", map[chroma.TokenType]string{chroma.Keyword: "bold #zzzzzz"})
+	if !errors.As(err, &styleErr) {
+		t.Fatalf("expected a StyleError for an invalid color, got %v", err)
+	}
+	assertEqual(t, "Keyword", styleErr.TokenType, "")
+	assertEqual(t, "bold #zzzzzz", styleErr.Entry, "")
+	if getStyle("broken").Name == "broken" {
+		t.Error("expected an invalid style to not be registered")
+	}
+
+	_, err = RegisterStyleXML(strings.NewReader(`<style name="broken"><entry type="Nonsense" style="#ffffff"/></style>`))
+	if !errors.As(err, &styleErr) || styleErr.TokenType != "Nonsense" {
+		t.Fatalf("expected a StyleError for an unknown token type, got %v", err)
+	}
+
+	_, err = RegisterStyleXML(strings.NewReader(`<style name="broken"><entry type="Keyword" style="#ffffff"/><entry type="Keyword" style="#000000"/></style>`))
+	if !errors.As(err, &styleErr) || !strings.Contains(err.Error(), "more than once") {
+		t.Fatalf("expected a StyleError for a duplicate token type, got %v", err)
+	}
+
+	_, err = RegisterStyleJSON(strings.NewReader(`{"name": "broken", "colors": {}}`))
+	if !errors.As(err, &styleErr) {
+		t.Fatalf("expected a StyleError for an unknown JSON field, got %v", err)
+	}
+}
+
+func TestRegisterStyleDir(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"test-brand.xml":  `<style name="Test Brand"><entry type="Background" style="bg:#ffffff"/><entry type="Keyword" style="bold #aa0055"/></style>`,
+		"test-other.json": `{"name": "Test Other", "entries": {"Keyword": "italic #00aa55"}}`,
+		"broken.xml":      `<style name="Test Broken"><entry type="Keyword" style="wobbly"/></style>`,
+		"README.md":       "not a style",
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	registered, err := RegisterStyleDir(dir)
+	assertEqual(t, 2, len(registered), "expected the valid styles to be registered")
+	var styleErr *StyleError
+	if !errors.As(err, &styleErr) || styleErr.Source != filepath.Join(dir, "broken.xml") {
+		t.Fatalf("expected a StyleError for broken.xml, got %v", err)
+	}
+
+	// Styles from files can also be found by their filename
+	assertEqual(t, "Test Brand", getStyle("test-brand").Name, "")
+	assertEqual(t, "Test Other", getStyle("Test Other").Name, "")
+}
@@ -0,0 +1,75 @@
+package splash
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/alecthomas/chroma/v2"
+)
+
+func TestDarkStyle(t *testing.T) {
+	input := []byte("<html><head></head><body><pre><code class=\"language-go\">func main() {}</code></pre></body></html>")
+	h := New(WithStyle("github"), WithDarkStyle("github-dark"))
+
+	_, cssBytes, err := h.Highlight(input)
+	if err != nil {
+		t.Fatal(err)
+	}
+	light, dark, found := bytes.Cut(cssBytes, []byte("@media (prefers-color-scheme: dark) {"))
+	if !found {
+		t.Fatalf("expected a media query for the dark style: %s", cssBytes)
+	}
+	if !bytes.Contains(light, []byte(".chroma .kd {")) || !bytes.Contains(dark, []byte(".chroma .kd {")) {
+		t.Errorf("expected the same class names for both styles: %s", cssBytes)
+	}
+	if !bytes.HasSuffix(bytes.TrimSpace(dark), []byte("}")) {
+		t.Errorf("expected the media query to be closed: %s", cssBytes)
+	}
+
+	// Splash adds the combined CSS
+	htmlBytes, err := h.Splash(input)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertEqual(t, 1, strings.Count(string(htmlBytes), "<style>"), "")
+	if !bytes.Contains(htmlBytes, []byte("@media (prefers-color-scheme: dark)")) {
+		t.Errorf("expected the dark style in the HTML: %s", htmlBytes)
+	}
+}
+
+func TestDarkStyleResetsLightProperties(t *testing.T) {
+	// The light style gives keywords a background, while the dark style does not
+	if _, err := RegisterStyle("Test Light Keyword Background", map[chroma.TokenType]string{
+		chroma.Background: "#222222 bg:#ffffff",
+		chroma.Keyword:    "bold #0000aa bg:#ffffcc",
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := RegisterStyle("Test Dark Plain Keyword", map[chroma.TokenType]string{
+		chroma.Background: "#eeeeee bg:#111111",
+		chroma.Keyword:    "#aaaaff",
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	input := []byte(`<pre><code class="language-go">func main() {}</code></pre>`)
+	_, cssBytes, err := New(WithStyle("Test Light Keyword Background"), WithDarkStyle("Test Dark Plain Keyword")).Highlight(input)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, dark, found := strings.Cut(string(cssBytes), "@media (prefers-color-scheme: dark) {")
+	if !found {
+		t.Fatalf("expected a media query for the dark style: %s", cssBytes)
+	}
+	start := strings.Index(dark, ".chroma .k {")
+	if start < 0 {
+		t.Fatalf("expected a rule for keywords in the dark style: %s", dark)
+	}
+	body := dark[start : start+strings.Index(dark[start:], "}")]
+	for _, reset := range []string{"background-color: transparent", "font-weight: inherit"} {
+		if !strings.Contains(body, reset) {
+			t.Errorf("expected %q in the dark rule for keywords: %s", reset, body)
+		}
+	}
+}
@@ -6,6 +6,7 @@ require (
 	github.com/alecthomas/chroma/v2 v2.24.2-0.20260509111935-786675b89cb3
 	github.com/go-git/go-git/v5 v5.16.4
 	github.com/russross/blackfriday v1.5.3-0.20190616195246-a925a152c144
+	golang.org/x/net v0.48.0
 )
 
 require (
@@ -26,7 +27,6 @@ require (
 	github.com/skeema/knownhosts v1.3.1 // indirect
 	github.com/xanzy/ssh-agent v0.3.3 // indirect
 	golang.org/x/crypto v0.47.0 // indirect
-	golang.org/x/net v0.48.0 // indirect
 	golang.org/x/sys v0.40.0 // indirect
 	gopkg.in/warnings.v0 v0.1.2 // indirect
 )
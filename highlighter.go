@@ -0,0 +1,546 @@
+package splash
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"html"
+	"io"
+	"slices"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/alecthomas/chroma/v2"
+	chromaHTML "github.com/alecthomas/chroma/v2/formatters/html"
+)
+
+// Highlighter holds the configuration for syntax highlighting code in HTML.
+// A Highlighter is never modified after it has been created, so it is safe
+// for concurrent use, and several Highlighters with different configurations
+// can be used at the same time.
+type Highlighter struct {
+	styleName        string
+	strictStyles     bool
+	darkStyleName    string
+	themes           []string
+	classPrefix      string
+	scopeSelector    string
+	defaultLanguage  string
+	classify         bool
+	minConfidence    float64
+	candidates       []string
+	unescape         bool
+	pruneCSS         bool
+	isolateErrors    bool
+	limits           Limits
+	inlineStyles     bool
+	lineNumbers      LineNumbers
+	lineStart        int
+	inlineCode       bool
+	minContrast      float64
+	contrastMode     ContrastMode
+	warningHandler   func(error)
+	languageHandler  func(LanguageChoice)
+	formatterOptions []chromaHTML.Option
+	formatter        *chromaHTML.Formatter
+	cssFormatters    [TableLineNumbers + 1]*chromaHTML.Formatter // for writing the CSS for each line number mode
+}
+
+// Option configures a Highlighter
+type Option func(*Highlighter)
+
+// WithStyle sets the syntax highlight style, like "monokai".
+// Full style list here: https://github.com/alecthomas/chroma/tree/master/styles
+func WithStyle(styleName string) Option {
+	return func(h *Highlighter) {
+		h.styleName = styleName
+	}
+}
+
+// WithDarkStyle sets a second style that is used when the reader prefers a
+// dark color scheme, like "github-dark" together with WithStyle("github").
+// Its CSS is placed within @media (prefers-color-scheme: dark), using the same
+// class names. Colors and font styles that only the first style sets are reset
+// there. This has no effect together with WithInlineStyles.
+func WithDarkStyle(styleName string) Option {
+	return func(h *Highlighter) {
+		h.darkStyleName = styleName
+	}
+}
+
+// WithDefaultLanguage sets the language that is used when no language is
+// specified and the language can not be detected. Must be supported by chroma.
+func WithDefaultLanguage(languageName string) Option {
+	return func(h *Highlighter) {
+		h.defaultLanguage = languageName
+	}
+}
+
+// WithUnescape can be set to true for unescaping already escaped code in <pre> tags,
+// which can be useful when highlighting code in newly rendered markdown.
+func WithUnescape(unescape bool) Option {
+	return func(h *Highlighter) {
+		h.unescape = unescape
+	}
+}
+
+// WithPrunedCSS can be set to true for only including the CSS rules for the
+// token classes that appear in the highlighted code, together with the rules
+// for the outer ".bg" and ".chroma" classes. This does not apply to
+// SplashStream, since it writes the CSS before the code has been highlighted.
+func WithPrunedCSS(prune bool) Option {
+	return func(h *Highlighter) {
+		h.pruneCSS = prune
+	}
+}
+
+// WithInlineStyles can be set to true for using inline style attributes
+// instead of CSS classes. Then no CSS is returned by Highlight, and Splash and
+// SplashStream do not add a <style> tag, so <head> is not needed. This is
+// useful for HTML fragments, and for places where <style> tags are removed.
+func WithInlineStyles(inline bool) Option {
+	return func(h *Highlighter) {
+		h.inlineStyles = inline
+	}
+}
+
+// WithClassPrefix sets a prefix for all CSS classes, both in the HTML and in
+// the CSS, so that "chroma" becomes "splash-chroma" and "k" becomes "splash-k"
+// with the prefix "splash-". This avoids collisions with other CSS on the page.
+func WithClassPrefix(prefix string) Option {
+	return func(h *Highlighter) {
+		h.classPrefix = prefix
+	}
+}
+
+// WithScopeSelector sets a CSS selector, like "#article .code", that all
+// generated CSS rules are placed under, so that they only apply within it.
+func WithScopeSelector(selector string) Option {
+	return func(h *Highlighter) {
+		h.scopeSelector = strings.TrimSpace(selector)
+	}
+}
+
+// WithFormatterOptions adds options for the chroma HTML formatter, like
+// chromaHTML.TabWidth(4). They are applied after the options splash needs.
+func WithFormatterOptions(options ...chromaHTML.Option) Option {
+	return func(h *Highlighter) {
+		h.formatterOptions = append(h.formatterOptions[:len(h.formatterOptions):len(h.formatterOptions)], options...)
+	}
+}
+
+// New creates a new Highlighter. The default style is "monokai" and the
+// default language is "shell".
+func New(options ...Option) *Highlighter {
+	h := &Highlighter{
+		styleName:       "monokai",
+		defaultLanguage: "shell",
+		lineStart:       1,
+	}
+	return h.with(options...)
+}
+
+// with returns a copy of the Highlighter, with the given options applied
+func (h *Highlighter) with(options ...Option) *Highlighter {
+	c := *h
+	for _, option := range options {
+		option(&c)
+	}
+	if len(c.formatterOptions) == 0 {
+		c.formatter = sharedFormatter(c.formatterConfig())
+		c.cssFormatters = c.newCSSFormatters()
+	} else if c.formatter == nil || len(c.formatterOptions) != len(h.formatterOptions) || c.formatterConfig() != h.formatterConfig() {
+		c.formatter = newFormatter(c.formatterConfig(), c.formatterOptions...)
+		c.cssFormatters = c.newCSSFormatters()
+	}
+	return &c
+}
+
+// style returns the style with the given name. In strict mode, unknown style
+// names are an error. If a minimum contrast has been set, the style is
+// checked, and either refused or adjusted.
+func (h *Highlighter) style(styleName string) (*chroma.Style, error) {
+	var style *chroma.Style
+	if h.strictStyles {
+		var err error
+		if style, err = LookupStyle(styleName); err != nil {
+			return nil, err
+		}
+	} else {
+		style = getStyle(styleName)
+	}
+	if h.minContrast > 0 {
+		return h.checkContrast(style)
+	}
+	return style, nil
+}
+
+// formatterConfig returns the settings that are passed on to the chroma HTML formatter
+func (h *Highlighter) formatterConfig() formatterConfig {
+	return formatterConfig{
+		inlineStyles: h.inlineStyles,
+		lineNumbers:  h.lineNumbers,
+		classPrefix:  h.classPrefix,
+	}
+}
+
+// Highlight takes HTML code as bytes and tries to syntax highlight code between
+// <pre> and </pre> tags. The HTML is tokenized, so tags in any case, with any
+// attributes, and wrapped as <pre>, <pre><code> or <code><pre> are all found.
+//
+// Returns the modified HTML source code and CSS style. If a block can not be
+// highlighted, a *BlockError is returned, or in isolation mode, BlockErrors
+// together with the HTML and CSS.
+func (h *Highlighter) Highlight(htmlData []byte) ([]byte, []byte, error) {
+	return h.highlight(context.Background(), htmlData, nil)
+}
+
+// highlight does the work for Highlight, HighlightContext and HighlightReport.
+// If report is not nil, an entry is added to it for each block of code.
+func (h *Highlighter) highlight(ctx context.Context, htmlData []byte, report *Report) ([]byte, []byte, error) {
+
+	// Try to use the given style name with robust lookup
+	style, err := h.style(h.styleName)
+	if err != nil {
+		return []byte{}, []byte{}, err
+	}
+
+	var (
+		htmlBuf    bytes.Buffer // buffer for generated HTML
+		scanner    = newBlockScanner(bytes.NewReader(htmlData), h.inlineBlocks(), h.maxBlockSize())
+		blockCount int
+		index      int             // the position of the next block, including the ones that failed
+		blockErrs  BlockErrors     // the blocks that failed, in isolation mode
+		used       map[string]bool // CSS classes used by the highlighted code, if the CSS is pruned
+		cssMode    = h.lineNumbers // the line number mode the CSS must support
+		overrides  []*chroma.Style // styles that override the document style for some blocks
+	)
+
+	if h.pruneCSS {
+		used = make(map[string]bool)
+	}
+
+	for {
+		// Read either HTML that should be passed through, or a block of code
+		passthrough, block, err := scanner.Scan()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return []byte{}, []byte{}, err
+		}
+		if block == nil {
+			htmlBuf.Write(passthrough.raw)
+			continue
+		}
+
+		if err := ctx.Err(); err != nil {
+			return []byte{}, []byte{}, err
+		}
+		start := time.Now()
+		rendered, blockErr := h.renderBlock(ctx, style, block, index)
+		index++
+		if err := ctx.Err(); err != nil {
+			return []byte{}, []byte{}, err
+		}
+		if blockErr != nil {
+			if !h.isolateErrors {
+				return []byte{}, []byte{}, blockErr
+			}
+			// Leave the block as it was
+			blockErrs = append(blockErrs, blockErr)
+			if report != nil {
+				report.add(block, rendered, time.Since(start), blockErr)
+			}
+			htmlBuf.Write(rendered.html)
+			continue
+		}
+		if report != nil {
+			report.add(block, rendered, time.Since(start), nil)
+		}
+		if used != nil {
+			collectClasses(rendered.html, used)
+		}
+		cssMode = max(cssMode, rendered.lineNumbers)
+		if rendered.style != style && !slices.Contains(overrides, rendered.style) {
+			overrides = append(overrides, rendered.style)
+		}
+		htmlBuf.Write(rendered.html)
+		blockCount++
+	}
+
+	if blockCount == 0 || h.inlineStyles {
+		// No CSS is needed
+		return htmlBuf.Bytes(), []byte{}, blockErrs.asError()
+	}
+
+	// Use the same CSS for all blocks
+	cssData, err := h.documentCSS(style, cssMode, overrides, used)
+	if err != nil {
+		return []byte{}, []byte{}, err
+	}
+
+	return htmlBuf.Bytes(), cssData, blockErrs.asError()
+}
+
+// documentCSS returns the CSS for a document with the given style, where
+// blocks use line number modes up to the given mode. Blocks with any of the
+// overriding styles get their own scoped CSS. If used is not nil, only the
+// rules for the used classes are included.
+func (h *Highlighter) documentCSS(style *chroma.Style, mode LineNumbers, overrides []*chroma.Style, used map[string]bool) ([]byte, error) {
+	formatter := h.cssFormatter(mode)
+	sheet, err := stylesheets.get(formatter, style)
+	if err != nil {
+		return nil, err
+	}
+	return h.sheetCSS(formatter, sheet, overrides, used)
+}
+
+// sheetCSS does the work for documentCSS, for the stylesheet of the document
+// style that has already been generated with the given formatter
+func (h *Highlighter) sheetCSS(formatter *chromaHTML.Formatter, sheet *stylesheet, overrides []*chroma.Style, used map[string]bool) ([]byte, error) {
+	if len(overrides) == 0 && used == nil && h.darkStyleName == "" && h.scopeSelector == "" && len(h.themes) == 0 {
+		// Return a copy, since the cached stylesheet must not be modified
+		return append([]byte(nil), sheet.stripped...), nil
+	}
+
+	var cssData []byte
+	if len(h.themes) > 0 {
+		themed, err := h.themedCSS(formatter, used)
+		if err != nil {
+			return nil, err
+		}
+		cssData = themed
+	} else {
+		cssData = h.finishCSS(sheet.css, used)
+	}
+	for _, override := range overrides {
+		overrideSheet, err := stylesheets.get(formatter, override)
+		if err != nil {
+			return nil, err
+		}
+		cssData = append(cssData, h.finishCSS(scopeCSS(overrideSheet.css, h.classPrefix+scopeClass(override), sheet.css, h.classPrefix), used)...)
+	}
+	if h.darkStyleName != "" && len(h.themes) == 0 {
+		darkStyle, err := h.style(h.darkStyleName)
+		if err != nil {
+			return nil, err
+		}
+		darkSheet, err := stylesheets.get(formatter, darkStyle)
+		if err != nil {
+			return nil, err
+		}
+		cssData = append(cssData, "@media (prefers-color-scheme: dark) {\n"...)
+		cssData = append(cssData, h.finishCSS(scopeCSS(darkSheet.css, "", sheet.css, h.classPrefix), used)...)
+		cssData = append(cssData, "}\n"...)
+	}
+	return stripCSS(cssData), nil
+}
+
+// finishCSS prunes the given CSS if used is not nil, and places the rules
+// under the scope selector, if one has been set.
+func (h *Highlighter) finishCSS(cssData []byte, used map[string]bool) []byte {
+	if used != nil {
+		cssData = pruneCSS(cssData, used)
+	}
+	if h.scopeSelector != "" {
+		cssData = prependSelector(cssData, h.scopeSelector)
+	}
+	return cssData
+}
+
+// Splash takes HTML code as bytes and tries to syntax highlight code between
+// <pre> and </pre> tags.
+//
+// Returns the modified HTML source code with embedded CSS as a <style> tag.
+// Requires the given HTML to contain </head> or <html>.
+// In isolation mode, BlockErrors may be returned together with the HTML.
+func (h *Highlighter) Splash(htmlData []byte) ([]byte, error) {
+	return h.splash(context.Background(), htmlData)
+}
+
+// splash does the work for Splash and SplashContext
+func (h *Highlighter) splash(ctx context.Context, htmlData []byte) ([]byte, error) {
+
+	HTML, CSS, highlightErr := h.highlight(ctx, htmlData, nil)
+	var blockErrs BlockErrors
+	if highlightErr != nil && !errors.As(highlightErr, &blockErrs) {
+		return []byte{}, highlightErr
+	}
+
+	if h.inlineStyles {
+		// No <style> tag is needed
+		return HTML, highlightErr
+	}
+
+	// Add all the generated CSS to a <style> tag in the generated HTML, without newlines
+	htmlBytes, err := AddCSSToHTML(HTML, CSS)
+	if err != nil {
+		return []byte{}, err
+	}
+
+	return htmlBytes, highlightErr
+}
+
+// renderedBlock is a block of code that has been highlighted
+type renderedBlock struct {
+	html        []byte         // the highlighted code, wrapped in the same tags as in the original HTML
+	lineNumbers LineNumbers    // the line number mode that was used
+	style       *chroma.Style  // the style that was used
+	language    LanguageChoice // how the language was chosen
+	lines       int            // the number of lines of code
+	errorTokens []string       // the text of the tokens that the lexer could not handle
+	limitErr    *LimitError    // the limit that made the block be left as plain text, if any
+}
+
+// highlightBlock syntax highlights the source code in the given block,
+// and returns it wrapped in the same tags as in the original HTML.
+func (h *Highlighter) highlightBlock(ctx context.Context, style *chroma.Style, block *codeBlock) (*renderedBlock, error) {
+	if block.wrapper == wrapInline {
+		return h.highlightInline(ctx, style, block)
+	}
+
+	source := h.blockSource(block)
+
+	// Try to find a suitable lexer
+	lexer, choice := h.chooseLexer(block, source)
+
+	// Stop tokenising when the time limit has passed or the context is done, and combine token runs
+	lexer, exceeded := h.timeLimited(lexer)
+	lexer = chroma.Coalesce(cancellable(ctx, lexer))
+
+	// Prepare to iterate over the tokens in the source code
+	iterator, err := lexer.Tokenise(nil, source)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrTokenise, err)
+	}
+	var errorTokens []string
+	iterator = collectErrorTokens(iterator, &errorTokens)
+
+	// Write the highlighted HTML to the hiBuf buffer
+	var hiBuf bytes.Buffer
+	blockStyle, err := h.blockStyle(style, block)
+	if err != nil {
+		return nil, err
+	}
+	lineNumbers, lineStart := h.lineNumbersFor(block)
+	lines := strings.Count(source, "\n") + 1
+	highlighted := h.highlightedLines(block, lines)
+	if err := h.blockFormatter(lineNumbers, lineStart, highlighted).Format(&hiBuf, blockStyle, iterator); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrFormat, err)
+	}
+	if limitErr := exceeded(); limitErr != nil {
+		return nil, limitErr
+	}
+	hiBytes := hiBuf.Bytes()
+
+	var blockHTML []byte
+	if lineNumbers == TableLineNumbers {
+		blockHTML = wrapTable(block, hiBytes, h.blockClass(style, blockStyle))
+	} else {
+		// With inline styles, the style for the <pre> tag is written by inlinePreWrapper
+		preStyle := ""
+		if h.inlineStyles {
+			hiBytes, preStyle = splitPreStyle(hiBytes)
+		}
+		blockHTML = block.wrap(hiBytes, h.blockClass(style, blockStyle), preStyle)
+	}
+
+	return &renderedBlock{
+		html:        blockHTML,
+		lineNumbers: lineNumbers,
+		style:       blockStyle,
+		language:    choice,
+		lines:       lines,
+		errorTokens: errorTokens,
+	}, nil
+}
+
+// blockSource returns the source code of the block, ready for highlighting
+func (h *Highlighter) blockSource(block *codeBlock) string {
+	// Trim away whitespace from only the end of the source code.
+	// There may be wanted indentation at the beginning of the string.
+	source := strings.TrimRightFunc(block.source, unicode.IsSpace)
+
+	// Unescape HTML, like &amp;, if this has already been done by ie. a Markdown renderer
+	if h.unescape {
+		source = html.UnescapeString(source)
+	}
+	return source
+}
+
+// formatterConfig holds the settings that splash passes on to the chroma
+// HTML formatter. It is comparable, so that formatters can be shared.
+type formatterConfig struct {
+	inlineStyles bool
+	inlineCode   bool
+	lineNumbers  LineNumbers
+	classPrefix  string
+}
+
+// newFormatter creates a chroma HTML formatter for the given configuration,
+// that leaves out the surrounding <pre> and <code> tags. Any given options
+// are applied last.
+func newFormatter(config formatterConfig, options ...chromaHTML.Option) *chromaHTML.Formatter {
+	var preWrapper chromaHTML.PreWrapper = nopPreWrapper{}
+	if config.lineNumbers == TableLineNumbers {
+		preWrapper = tablePreWrapper{}
+	} else if config.inlineStyles {
+		preWrapper = inlinePreWrapper{}
+	}
+	options = append([]chromaHTML.Option{
+		chromaHTML.WithClasses(!config.inlineStyles),
+		chromaHTML.InlineCode(config.inlineCode),
+		chromaHTML.WithPreWrapper(preWrapper),
+		chromaHTML.WithLineNumbers(config.lineNumbers != NoLineNumbers),
+		chromaHTML.LineNumbersInTable(config.lineNumbers == TableLineNumbers),
+		chromaHTML.ClassPrefix(config.classPrefix),
+	}, options...)
+	return chromaHTML.New(options...)
+}
+
+// nopPreWrapper makes chroma leave out the surrounding <pre> and <code> tags,
+// since the tags of the original HTML are kept.
+type nopPreWrapper struct{}
+
+func (nopPreWrapper) Start(code bool, styleAttr string) string { return "" }
+func (nopPreWrapper) End(code bool) string                     { return "" }
+
+// inlinePreWrapper makes chroma write a <pre> tag with only the inline style,
+// so that the style can be moved over to the <pre> or <code> tag of the original HTML.
+type inlinePreWrapper struct{}
+
+func (inlinePreWrapper) Start(code bool, styleAttr string) string {
+	if code {
+		return "<pre" + styleAttr + ">"
+	}
+	return ""
+}
+
+func (inlinePreWrapper) End(code bool) string {
+	if code {
+		return "</pre>"
+	}
+	return ""
+}
+
+// splitPreStyle removes the surrounding <pre> and </pre> tags that were
+// written by inlinePreWrapper, and returns the inner HTML and the inline style.
+func splitPreStyle(hiBytes []byte) ([]byte, string) {
+	if !bytes.HasPrefix(hiBytes, []byte("<pre")) || !bytes.HasSuffix(hiBytes, []byte("</pre>")) {
+		return hiBytes, ""
+	}
+	end := bytes.IndexByte(hiBytes, '>')
+	preTag := string(hiBytes[:end])
+	hiBytes = hiBytes[end+1 : len(hiBytes)-len("</pre>")]
+	const styleAttr = ` style="`
+	start := strings.Index(preTag, styleAttr)
+	if start == -1 {
+		return hiBytes, ""
+	}
+	// The style attribute is already escaped by chroma, so unescape it before it is escaped again
+	preStyle := strings.TrimSuffix(preTag[start+len(styleAttr):], `"`)
+	return hiBytes, html.UnescapeString(preStyle)
+}
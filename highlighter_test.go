@@ -0,0 +1,72 @@
+package splash
+
+import (
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestHighlighterDefaultLanguage(t *testing.T) {
+	// "x = 1" can not be detected, so the default language is used
+	input := []byte("<html><body><pre>x = 1</pre></body></html>")
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			languageName, expected := "text", `<span class="cl">x = 1</span>`
+			if i%2 == 0 {
+				languageName, expected = "python", `<span class="o">=</span>`
+			}
+			h := New(WithStyle("github"), WithDefaultLanguage(languageName))
+			htmlBytes, err := h.Splash(input)
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			if !strings.Contains(string(htmlBytes), expected) {
+				t.Errorf("expected %s to be highlighted as %s: %s", input, languageName, htmlBytes)
+			}
+		}(i)
+	}
+	wg.Wait()
+}
+
+func TestSetDefaultLanguage(t *testing.T) {
+	defer SetDefaultLanguage("shell")
+	SetDefaultLanguage("python")
+	htmlBytes, _, err := Highlight([]byte("<pre>x = 1</pre>"), "github", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(htmlBytes), `<span class="o">=</span>`) {
+		t.Errorf("expected the code to be highlighted as python: %s", htmlBytes)
+	}
+}
+
+func TestInlineStyles(t *testing.T) {
+	h := New(WithStyle("monokai"), WithInlineStyles(true))
+
+	// A fragment without <head> or <html>
+	htmlBytes, err := h.Splash([]byte(`<p>Hi</p><pre style="margin: 0"><code class="language-go">func main() {}</code></pre>`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	output := string(htmlBytes)
+	if strings.Contains(output, "<style>") || strings.Contains(output, `<span class="`) {
+		t.Errorf("expected only inline styles: %s", output)
+	}
+	if !strings.HasPrefix(output, `<p>Hi</p><pre style="color:#f8f8f2;background-color:#272822;`) || !strings.Contains(output, `; margin: 0" class="chroma">`) {
+		t.Errorf("expected the background and the existing style on the <pre> tag: %s", output)
+	}
+	if !strings.Contains(output, `<span style="color:#66d9ef">func</span>`) {
+		t.Errorf("expected inline styles for the tokens: %s", output)
+	}
+
+	_, cssBytes, err := h.Highlight([]byte(languageBlock))
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertEqual(t, 0, len(cssBytes), "no CSS is needed with inline styles")
+}
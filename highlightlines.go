@@ -0,0 +1,87 @@
+package splash
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// LineRangeError is reported as a warning when a line range in a data-line
+// or data-highlight attribute is invalid or outside of the block.
+type LineRangeError struct {
+	Range  string // the range, as written in the attribute
+	Lines  int    // the number of lines in the block
+	Reason string // why the range is invalid
+}
+
+func (e *LineRangeError) Error() string {
+	return fmt.Sprintf("invalid line range %q for a block with %d lines: %s", e.Range, e.Lines, e.Reason)
+}
+
+// WithWarningHandler sets a function that is called for problems that do not
+// stop the highlighting, like line ranges that are outside of a block.
+func WithWarningHandler(handler func(error)) Option {
+	return func(h *Highlighter) {
+		h.warningHandler = handler
+	}
+}
+
+// warn reports the given warning, if a warning handler has been set
+func (h *Highlighter) warn(err error) {
+	if h.warningHandler != nil {
+		h.warningHandler(err)
+	}
+}
+
+// highlightedLines returns the line ranges that should be highlighted for the
+// given block, from a data-line or data-highlight attribute like "3,7-9".
+// Lines are counted from 1, regardless of where the line numbers start.
+// Invalid ranges are reported as warnings and left out.
+func (h *Highlighter) highlightedLines(block *codeBlock, lineCount int) [][2]int {
+	spec, ok := block.attr("data-line")
+	if !ok {
+		if spec, ok = block.attr("data-highlight"); !ok {
+			return nil
+		}
+	}
+	ranges, errs := parseLineRanges(spec, lineCount)
+	for _, err := range errs {
+		h.warn(err)
+	}
+	return ranges
+}
+
+// parseLineRanges parses line ranges like "3,7-9" and checks them against
+// the given number of lines. Ranges that end after the last line are cut short.
+func parseLineRanges(spec string, lineCount int) ([][2]int, []error) {
+	var (
+		ranges [][2]int
+		errs   []error
+	)
+	fields := strings.FieldsFunc(spec, func(r rune) bool {
+		return r == ',' || r == ' '
+	})
+	for _, field := range fields {
+		first, last, isRange := strings.Cut(field, "-")
+		if !isRange {
+			last = first
+		}
+		from, err1 := strconv.Atoi(first)
+		to, err2 := strconv.Atoi(last)
+		switch {
+		case err1 != nil || err2 != nil:
+			errs = append(errs, &LineRangeError{Range: field, Lines: lineCount, Reason: "not a line number or a range of line numbers"})
+		case from < 1 || to < from:
+			errs = append(errs, &LineRangeError{Range: field, Lines: lineCount, Reason: "the range is empty or starts before the first line"})
+		case from > lineCount:
+			errs = append(errs, &LineRangeError{Range: field, Lines: lineCount, Reason: "the range starts after the last line"})
+		default:
+			if to > lineCount {
+				errs = append(errs, &LineRangeError{Range: field, Lines: lineCount, Reason: "the range ends after the last line"})
+				to = lineCount
+			}
+			ranges = append(ranges, [2]int{from, to})
+		}
+	}
+	return ranges, errs
+}
@@ -0,0 +1,45 @@
+package splash
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestHighlightedLines(t *testing.T) {
+	var warnings []error
+	h := New(WithWarningHandler(func(err error) {
+		warnings = append(warnings, err)
+	}))
+
+	input := strings.Replace(threeLines, "<pre>", `<pre data-line="2-3,7">`, 1)
+	htmlBytes, _, err := h.Highlight([]byte(input))
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertEqual(t, 2, strings.Count(string(htmlBytes), `<span class="line hl">`), "expected two highlighted lines: "+string(htmlBytes))
+	assertEqual(t, 1, len(warnings), "expected a warning for line 7")
+	var rangeErr *LineRangeError
+	if !errors.As(warnings[0], &rangeErr) || rangeErr.Range != "7" || rangeErr.Lines != 3 {
+		t.Errorf("unexpected warning: %v", warnings[0])
+	}
+
+	// data-highlight on <code>, with line numbers that start at 10
+	input = strings.Replace(threeLines, "<code ", `<code data-highlight="1" data-line-numbers="true" data-start="10" `, 1)
+	htmlBytes, _, err = h.Highlight([]byte(input))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(htmlBytes), `<span class="line hl"><span class="ln">10</span>`) {
+		t.Errorf("expected the first line to be highlighted: %s", htmlBytes)
+	}
+}
+
+func TestParseLineRanges(t *testing.T) {
+	ranges, errs := parseLineRanges("3, 7-9,x,5-4,9-12", 10)
+	assertEqual(t, 3, len(ranges), "")
+	assertEqual(t, [2]int{3, 3}, ranges[0], "")
+	assertEqual(t, [2]int{7, 9}, ranges[1], "")
+	assertEqual(t, [2]int{9, 10}, ranges[2], "")
+	assertEqual(t, 3, len(errs), "expected warnings for x, 5-4 and 9-12")
+}
@@ -0,0 +1,129 @@
+package splash
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"html"
+	"regexp"
+	"strings"
+
+	"github.com/alecthomas/chroma/v2"
+)
+
+// languageSuffixRegexp matches a language suffix like {:go} at the end of inline code
+var languageSuffixRegexp = regexp.MustCompile(`\{:([A-Za-z0-9_+#.-]+)\}$`)
+
+// WithInlineCode can be set to true for also highlighting <code> elements
+// that are not part of a <pre> block, like `inline code` in Markdown.
+// The language must be given, either with a class like "language-go", or
+// with a suffix like `fmt.Println(x){:go}`. Inline code without a known
+// language is left as it is, and does not count as a block of code. The
+// highlighted code stays within the <code> element.
+func WithInlineCode(inline bool) Option {
+	return func(h *Highlighter) {
+		h.inlineCode = inline
+	}
+}
+
+// inlineLanguage returns the language of the given inline code block,
+// and the source code without any language suffix.
+func inlineLanguage(block *codeBlock, source string) (string, string) {
+	if match := languageSuffixRegexp.FindStringSubmatchIndex(source); match != nil {
+		return source[match[2]:match[3]], source[:match[0]]
+	}
+	return block.language(), source
+}
+
+// inlineBlocks returns the function that decides which inline <code>
+// elements are returned as blocks by the scanner, or nil if inline code is
+// not highlighted. Inline code without a known language is passed through as
+// HTML, so that it gets no CSS, no entry in the report and does not count
+// towards MaxBlocks.
+func (h *Highlighter) inlineBlocks() func(*codeBlock) bool {
+	if !h.inlineCode {
+		return nil
+	}
+	return func(block *codeBlock) bool {
+		language, _ := inlineLanguage(block, block.source)
+		return language != "" && h.lexerByName(language) != nil
+	}
+}
+
+// highlightInline syntax highlights the given inline <code> element, which
+// has a known language
+func (h *Highlighter) highlightInline(ctx context.Context, style *chroma.Style, block *codeBlock) (*renderedBlock, error) {
+	language, source := inlineLanguage(block, block.source)
+	fromSuffix := source != block.source
+	lexer := h.lexerByName(language)
+	if lexer == nil {
+		return nil, fmt.Errorf("%w: unknown language %q", ErrTokenise, language)
+	}
+
+	// Unescape HTML, like &amp;, if this has already been done by ie. a Markdown renderer
+	if h.unescape {
+		source = html.UnescapeString(source)
+	}
+
+	lexer, exceeded := h.timeLimited(lexer)
+	iterator, err := chroma.Coalesce(cancellable(ctx, lexer)).Tokenise(nil, source)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrTokenise, err)
+	}
+	var errorTokens []string
+	iterator = collectErrorTokens(iterator, &errorTokens)
+
+	// Remove the newline that many lexers add at the end, since it would
+	// show up as a space after the inline code
+	tokens := iterator.Tokens()
+	if limitErr := exceeded(); limitErr != nil {
+		return nil, limitErr
+	}
+	for len(tokens) > 0 && !strings.HasSuffix(source, "\n") {
+		last := &tokens[len(tokens)-1]
+		if !strings.HasSuffix(last.Value, "\n") {
+			break
+		}
+		last.Value = strings.TrimSuffix(last.Value, "\n")
+		if last.Value == "" {
+			tokens = tokens[:len(tokens)-1]
+		}
+	}
+
+	config := h.formatterConfig()
+	config.lineNumbers = NoLineNumbers
+	config.inlineCode = true
+	formatter := sharedFormatter(config)
+	if len(h.formatterOptions) > 0 {
+		formatter = newFormatter(config, h.formatterOptions...)
+	}
+
+	blockStyle, err := h.blockStyle(style, block)
+	if err != nil {
+		return nil, err
+	}
+
+	var hiBuf bytes.Buffer
+	if err := formatter.Format(&hiBuf, blockStyle, chroma.Literator(tokens...)); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrFormat, err)
+	}
+	hiBytes := hiBuf.Bytes()
+
+	// With inline styles, the style for the <code> tag is written by inlinePreWrapper
+	codeStyle := ""
+	if h.inlineStyles {
+		hiBytes, codeStyle = splitPreStyle(hiBytes)
+	}
+
+	choice := LanguageChoice{Lexer: lexer.Config().Name, Method: LanguageFromClass, Hint: "language-" + language, Reason: fmt.Sprintf("the class %q", "language-"+language)}
+	if fromSuffix {
+		choice = LanguageChoice{Lexer: lexer.Config().Name, Method: LanguageFromSuffix, Hint: "{:" + language + "}", Reason: fmt.Sprintf("the suffix %q", "{:"+language+"}")}
+	}
+	return &renderedBlock{
+		html:        block.wrap(hiBytes, h.blockClass(style, blockStyle), codeStyle),
+		style:       blockStyle,
+		language:    choice,
+		lines:       strings.Count(source, "\n") + 1,
+		errorTokens: errorTokens,
+	}, nil
+}
@@ -0,0 +1,70 @@
+package splash
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/russross/blackfriday"
+)
+
+func TestInlineCode(t *testing.T) {
+	markdown := "Call `fmt.Println(x){:go}` or <code class=\"language-go\">len(s)</code>, but not `inline stuff`.\n\n    package main\n"
+	input := blackfriday.MarkdownCommon([]byte(markdown))
+
+	htmlBytes, _, err := New(WithInlineCode(true), WithUnescape(true)).Highlight(input)
+	if err != nil {
+		t.Fatal(err)
+	}
+	output := string(htmlBytes)
+
+	if !strings.Contains(output, `<code class="chroma"><span class="nx">fmt</span><span class="p">.</span><span class="nf">Println</span><span class="p">(</span><span class="nx">x</span><span class="p">)</span></code>`) {
+		t.Errorf("expected the inline code with a suffix to be highlighted: %s", output)
+	}
+	if !strings.Contains(output, `<code class="language-go chroma"><span class="nb">len</span>`) {
+		t.Errorf("expected the inline code with a class to be highlighted: %s", output)
+	}
+	if !strings.Contains(output, "<code>inline stuff</code>") {
+		t.Errorf("expected the inline code without a language to be left as it is: %s", output)
+	}
+	if strings.Contains(output, "{:go}") {
+		t.Errorf("expected the language suffix to be removed: %s", output)
+	}
+	if !strings.Contains(output, `<pre class="chroma"><code><span class="line">`) {
+		t.Errorf("expected the code block to be highlighted: %s", output)
+	}
+
+	// Without the option, inline code is not touched
+	htmlBytes, _, err = Highlight(input, "monokai", true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Contains(htmlBytes, []byte("<code>fmt.Println(x){:go}</code>")) {
+		t.Errorf("expected the inline code to be left as it is: %s", htmlBytes)
+	}
+}
+
+func TestInlineCodeWithoutLanguage(t *testing.T) {
+	// Inline code without a known language is passed through as HTML, and is not a block
+	input := []byte(`<p>Run <code>make</code> or <code class="language-nonsense">x</code>, then <code class="language-go">len(s)</code>.</p>`)
+	h := New(WithInlineCode(true), WithLimits(Limits{MaxBlocks: 1}))
+	htmlBytes, cssBytes, report, err := h.HighlightReport(input)
+	if err != nil {
+		t.Fatal(err)
+	}
+	output := string(htmlBytes)
+	if !strings.Contains(output, `<code>make</code> or <code class="language-nonsense">x</code>`) {
+		t.Errorf("expected the inline code without a known language to be left as it is: %s", output)
+	}
+	if !strings.Contains(output, `<code class="language-go chroma"><span class="nb">len</span>`) {
+		t.Errorf("expected the inline code with a language to be highlighted, within MaxBlocks: %s", output)
+	}
+	assertEqual(t, 1, len(report.Blocks), "expected only the highlighted inline code in the report")
+
+	// Without any highlighted code, no CSS is needed
+	_, cssBytes, err = h.Highlight([]byte(`<p>Run <code>make</code>.</p>`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertEqual(t, 0, len(cssBytes), "expected no CSS for inline code without a language")
+}
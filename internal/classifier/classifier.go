@@ -0,0 +1,187 @@
+// Package classifier guesses the language of source code with a naive Bayes
+// classifier over tokens. The model is trained offline by cmd/trainclassifier.
+package classifier
+
+import (
+	"maps"
+	"math"
+	"slices"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// Model holds the token counts per language
+type Model struct {
+	Languages  map[string]Language // lexer name -> token counts
+	Vocabulary int                 // the number of distinct tokens in the training samples
+}
+
+// Language holds the token counts for one language
+type Language struct {
+	Total  int            // the number of tokens in the training samples, including the pruned ones
+	Tokens map[string]int // the most common tokens and how often they appear
+}
+
+// Guess is a language together with how likely it is, from 0 to 1
+type Guess struct {
+	Language   string
+	Confidence float64
+}
+
+const (
+	// smoothing is added to all token counts, so that unseen tokens do not rule out a language
+	smoothing = 0.5
+	// temperature scales the average log likelihood per token before the
+	// scores are turned into probabilities, since naive Bayes is overconfident
+	temperature = 4.0
+	// minTokens is the minimum number of known tokens for making a guess
+	minTokens = 3
+)
+
+// symbols are the characters that make up operator tokens, like ":=" or "->"
+const symbols = "!#$%&*+-./:;<=>?@\\^|~"
+
+// Tokenize splits source code into identifiers, keywords and operators.
+// Numbers, strings of whitespace and single letters are left out.
+func Tokenize(source string) []string {
+	var tokens []string
+	runes := []rune(source)
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		switch {
+		case unicode.IsLetter(r) || r == '_':
+			start := i
+			for i < len(runes) && (unicode.IsLetter(runes[i]) || unicode.IsDigit(runes[i]) || runes[i] == '_') {
+				i++
+			}
+			if i-start > 1 {
+				tokens = append(tokens, string(runes[start:i]))
+			}
+		case strings.ContainsRune(symbols, r):
+			start := i
+			for i < len(runes) && i-start < 3 && strings.ContainsRune(symbols, runes[i]) {
+				i++
+			}
+			tokens = append(tokens, string(runes[start:i]))
+		case strings.ContainsRune("(){}[]", r):
+			tokens = append(tokens, string(r))
+			i++
+		default:
+			i++
+		}
+	}
+	return tokens
+}
+
+// Train counts the tokens in the samples for each language, and keeps the
+// given number of the most common tokens per language
+func Train(samples map[string][]string, keep int) *Model {
+	model := &Model{Languages: make(map[string]Language)}
+	vocabulary := make(map[string]bool)
+	for language, texts := range samples {
+		counts := make(map[string]int)
+		total := 0
+		for _, text := range texts {
+			for _, token := range Tokenize(text) {
+				counts[token]++
+				vocabulary[token] = true
+				total++
+			}
+		}
+		model.Languages[language] = Language{Total: total, Tokens: mostCommon(counts, keep)}
+	}
+	model.Vocabulary = len(vocabulary)
+	return model
+}
+
+// mostCommon returns the given number of tokens with the highest counts
+func mostCommon(counts map[string]int, keep int) map[string]int {
+	tokens := make([]string, 0, len(counts))
+	for token := range counts {
+		tokens = append(tokens, token)
+	}
+	sort.Slice(tokens, func(i, j int) bool {
+		if counts[tokens[i]] != counts[tokens[j]] {
+			return counts[tokens[i]] > counts[tokens[j]]
+		}
+		return tokens[i] < tokens[j]
+	})
+	if len(tokens) > keep {
+		tokens = tokens[:keep]
+	}
+	kept := make(map[string]int, len(tokens))
+	for _, token := range tokens {
+		kept[token] = counts[token]
+	}
+	return kept
+}
+
+// Classify returns the languages of the model, or only the given candidates,
+// ranked by how likely they are for the given source code. The confidences
+// are always for all the languages of the model, so that they add up to 1
+// before the candidates are picked out. Nil is returned if the source has
+// too few tokens that the model knows.
+func (m *Model) Classify(source string, candidates []string) []Guess {
+	// Each distinct token is counted once, so that repetition does not dominate
+	known := make(map[string]bool)
+	for _, token := range Tokenize(source) {
+		for _, counts := range m.Languages {
+			if _, ok := counts.Tokens[token]; ok {
+				known[token] = true
+				break
+			}
+		}
+	}
+	if len(known) < minTokens {
+		return nil
+	}
+
+	// The tokens and languages are sorted, so that the sums of the floating
+	// point scores, and with them the confidences, are the same on every run
+	tokens := slices.Sorted(maps.Keys(known))
+	languages := slices.Sorted(maps.Keys(m.Languages))
+
+	guesses := make([]Guess, 0, len(languages))
+	scores := make([]float64, 0, len(languages))
+	best := math.Inf(-1)
+	for _, language := range languages {
+		counts := m.Languages[language]
+		denominator := math.Log(float64(counts.Total) + smoothing*float64(m.Vocabulary))
+		score := 0.0
+		for _, token := range tokens {
+			score += math.Log(float64(counts.Tokens[token])+smoothing) - denominator
+		}
+		score = score / float64(len(known)) * temperature
+		guesses = append(guesses, Guess{Language: language})
+		scores = append(scores, score)
+		best = max(best, score)
+	}
+
+	// Turn the scores into probabilities
+	sum := 0.0
+	for i, score := range scores {
+		scores[i] = math.Exp(score - best)
+		sum += scores[i]
+	}
+	for i := range guesses {
+		guesses[i].Confidence = scores[i] / sum
+	}
+
+	if len(candidates) > 0 {
+		picked := guesses[:0]
+		for _, guess := range guesses {
+			if slices.Contains(candidates, guess.Language) {
+				picked = append(picked, guess)
+			}
+		}
+		guesses = picked
+	}
+	sort.Slice(guesses, func(i, j int) bool {
+		if guesses[i].Confidence != guesses[j].Confidence {
+			return guesses[i].Confidence > guesses[j].Confidence
+		}
+		return guesses[i].Language < guesses[j].Language
+	})
+	return guesses
+}
@@ -0,0 +1,61 @@
+package classifier
+
+import (
+	"math"
+	"reflect"
+	"testing"
+)
+
+func TestTokenize(t *testing.T) {
+	got := Tokenize("x := map[string]int{} // a comment 42")
+	want := []string{":=", "map", "[", "string", "]", "int", "{", "}", "//", "comment"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("expected %q, got %q", want, got)
+	}
+}
+
+func TestTrainAndClassify(t *testing.T) {
+	model := Train(map[string][]string{
+		"Go":     {"func main() { x := 1; fmt.Println(x) }"},
+		"Python": {"def main():\n    x = 1\n    print(x)"},
+	}, 100)
+	guesses := model.Classify("func f() { y := 2 }", nil)
+	if len(guesses) != 2 || guesses[0].Language != "Go" {
+		t.Fatalf("expected Go first, got %v", guesses)
+	}
+	if sum := guesses[0].Confidence + guesses[1].Confidence; sum < 0.999 || sum > 1.001 {
+		t.Errorf("expected the confidences to add up to 1, got %f", sum)
+	}
+	if picked := model.Classify("func f() { y := 2 }", []string{"Python"}); len(picked) != 1 || picked[0].Language != "Python" || math.Abs(picked[0].Confidence-guesses[1].Confidence) > 1e-9 {
+		t.Errorf("expected only Python with the same confidence, got %v", picked)
+	}
+	if guesses := model.Classify("hello", nil); guesses != nil {
+		t.Errorf("expected no guesses for too few tokens, got %v", guesses)
+	}
+}
+
+func TestClassifyIsDeterministic(t *testing.T) {
+	source := "x := make(map[string]int)\nif err != nil {\n\treturn err\n}"
+	first := Default.Classify(source, nil)
+	for range 50 {
+		if guesses := Default.Classify(source, nil); !reflect.DeepEqual(guesses, first) {
+			t.Fatalf("expected the same guesses on every run, got %v and %v", first, guesses)
+		}
+	}
+}
+
+func TestDefaultModel(t *testing.T) {
+	tests := map[string]string{
+		"Go":     "x := make(map[string]int)\nif err != nil {\n\treturn err\n}",
+		"Python": "def greet(name):\n    print(f\"hello {name}\")\n    return None\n",
+		"YAML":   "name: test\nitems:\n  - one\n  - two\nenabled: true\n",
+		"Rust":   "fn main() {\n    let mut x = vec![1, 2];\n    println!(\"{:?}\", x);\n}",
+		"SQL":    "SELECT name FROM users WHERE id = 1;",
+	}
+	for language, source := range tests {
+		guesses := Default.Classify(source, nil)
+		if len(guesses) == 0 || guesses[0].Language != language {
+			t.Errorf("expected %s for %q, got %v", language, source, guesses)
+		}
+	}
+}
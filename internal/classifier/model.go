@@ -0,0 +1,37 @@
+package classifier
+
+// This file is auto-generated by cmd/trainclassifier
+// It holds the token counts for the samples in internal/classifier/testdata
+
+// Default is the model that is trained on the samples
+var Default = &Model{
+	Vocabulary: 1555,
+	Languages: map[string]Language{
+		"Bash":       {Total: 487, Tokens: map[string]int{"!": 1, "#": 1, "#!/": 2, "$": 31, "$*": 1, "$@": 1, "&&": 4, "(": 10, ")": 15, "*": 3, "*.": 1, "+": 2, "+%": 1, "-": 30, "--": 1, ".": 15, "...": 1, "./": 1, "/": 23, "/*": 1, ":": 4, ":$": 1, ":%": 2, ":-": 1, "://": 3, ";": 11, ";;": 5, "<<": 1, "=": 9, "=$": 5, "=/": 1, ">": 3, ">&": 4, "?": 1, "BUILD_DIR": 4, "DEST": 5, "EDITOR": 1, "END": 2, "EXIT": 1, "HOME": 2, "Install": 1, "OPTARG": 1, "OPTIND": 1, "OUTPUT": 2, "PATH": 2, "Please": 1, "Skipping": 1, "Start": 1, "TMPDIR": 1, "Usage": 1, "VERBOSE": 2, "VERSION": 3, "[": 12, "]": 12, "^": 1, "alias": 1, "answer": 2, "app": 8, "application": 1, "apt": 1, "as": 1, "attempt": 1, "awk": 1, "bash": 1, "bashrc": 1, "bin": 6, "build": 1, "building": 1, "case": 2, "cat": 1, "cd": 2, "chmod": 1, "cleanup": 1, "clone": 1, "com": 2, "command": 1, "count": 4, "cp": 1, "curl": 3, "czf": 1, "date": 1, "dev": 2, "dir": 3, "dist": 1, "do": 5, "done": 6, "echo": 8, "elif": 1, "enable": 1, "esac": 2, "euo": 1, "example": 2, "exit": 4, "export": 2, "fi": 5, "files": 3, "find": 1, "foo": 2, "for": 3, "found": 1, "fsSL": 1, "function": 1, "get": 1, "getopts": 1, "git": 4, "github": 1, "grep": 1, "gz": 3, "http": 1, "https": 2, "id": 1, "if": 5, "in": 5, "install": 3, "la": 1, "ll": 1, "ln": 1, "local": 2, "localhost": 1, "log": 2, "ls": 2, "make": 1, "mkdir": 2, "name": 1, "ne": 1, "not": 1, "now": 2, "null": 2, "opt": 3, "output": 1, "pipefail": 1, "print": 1, "pwd": 1, "read": 1, "return": 1, "rf": 2, "rm": 4, "service": 2, "sh": 4, "sleep": 2, "tar": 4, "target": 8, "the": 2, "then": 6, "true": 2, "usage": 2, "version": 2, "{": 5, "|": 4, "}": 5}},
+		"C":          {Total: 554, Tokens: map[string]int{"!": 2, "!=": 2, "#": 10, "%": 3, "(": 55, ")": 55, "*": 23, "**": 1, "+": 2, "++": 3, "++;": 1, "-": 1, "->": 16, ".": 6, ":": 3, ";": 56, "<": 8, "<<": 1, "=": 22, "==": 3, ">": 6, "?": 1, "BLUE": 1, "BUFFER_SIZE": 2, "DEBUG": 1, "EXIT_FAILURE": 1, "FILE": 1, "GREEN": 1, "MAX": 2, "NULL": 6, "RED": 1, "[": 6, "\\": 3, "]": 6, "argc": 2, "argv": 3, "assert": 1, "buffer": 5, "calloc": 2, "cap": 11, "char": 5, "color": 1, "const": 3, "debug": 1, "define": 2, "endif": 1, "enum": 1, "exit": 1, "fclose": 1, "fgets": 1, "file": 1, "fopen": 1, "for": 2, "fp": 5, "fprintf": 1, "free": 3, "free_list": 2, "goto": 1, "hash": 1, "head": 7, "if": 6, "ifdef": 1, "include": 6, "inline": 1, "int": 12, "items": 10, "len": 6, "list": 11, "list_append": 1, "list_free": 1, "list_new": 1, "main": 1, "malloc": 2, "memset": 1, "next": 5, "node": 2, "node_t": 8, "out": 2, "perror": 1, "print_all": 1, "printf": 2, "push": 2, "realloc": 1, "return": 10, "size_t": 6, "sizeof": 5, "static": 3, "stderr": 1, "stdint": 1, "stdio": 1, "stdlib": 1, "string": 1, "strlen": 1, "struct": 8, "typedef": 1, "uint32_t": 2, "unsigned": 2, "usage": 1, "value": 6, "version": 1, "void": 4, "while": 3, "{": 18, "}": 18}},
+		"C#":         {Total: 295, Tokens: map[string]int{"!": 3, "$": 2, "(": 22, ")": 22, ".": 21, ":": 1, ";": 23, "<": 7, "=": 6, "=>": 2, ">": 7, "App": 1, "Collections": 1, "Console": 3, "Customer": 4, "CustomerRepository": 2, "Delay": 1, "Dictionary": 1, "Empty": 1, "Error": 1, "Example": 1, "Generic": 1, "GetAsync": 3, "Hello": 1, "IRepository": 2, "Id": 2, "IsNullOrEmpty": 1, "KeyNotFoundException": 2, "Linq": 1, "List": 2, "Main": 1, "Message": 1, "Name": 2, "No": 1, "Order": 3, "Orders": 1, "Program": 1, "Select": 1, "Serializable": 1, "System": 4, "Task": 4, "Tasks": 1, "Threading": 1, "ToList": 1, "ToUpper": 1, "Total": 1, "TryGetValue": 1, "Where": 1, "WriteLine": 3, "[": 2, "]": 2, "_customers": 2, "args": 2, "async": 2, "await": 2, "catch": 1, "class": 3, "customer": 5, "decimal": 1, "ex": 2, "foreach": 1, "get": 3, "id": 4, "if": 1, "in": 1, "int": 5, "interface": 1, "internal": 1, "name": 2, "names": 2, "namespace": 1, "new": 4, "out": 1, "private": 1, "public": 9, "readonly": 1, "record": 1, "repository": 2, "return": 1, "sealed": 1, "set": 2, "static": 2, "string": 4, "throw": 1, "try": 1, "using": 4, "var": 5, "{": 16, "}": 16}},
+		"C++":        {Total: 337, Tokens: map[string]int{"#": 5, "&": 9, "(": 24, ")": 24, "*": 3, "+": 2, "->": 4, ".": 6, "//": 1, ":": 7, "::": 16, ";": 23, "<": 10, "<<": 7, "=": 6, ">": 7, ">>": 1, "Circle": 3, "Shape": 4, "[": 4, "\\": 1, "]": 4, "algorithm": 1, "area": 6, "auto": 4, "begin": 1, "catch": 1, "cerr": 1, "clamp": 1, "class": 2, "const": 10, "constexpr": 1, "cout": 2, "default": 1, "delete": 1, "double": 4, "end": 1, "endl": 2, "exception": 1, "explicit": 1, "for": 1, "geometry": 4, "greet": 2, "greeting": 2, "hello": 1, "high": 2, "include": 5, "int": 4, "iostream": 1, "low": 2, "main": 1, "make_unique": 1, "max": 1, "memory": 1, "min": 1, "name": 3, "namespace": 3, "new": 1, "oops": 1, "override": 1, "private": 1, "public": 3, "push_back": 1, "radius": 2, "radius_": 4, "return": 5, "runtime_error": 1, "shape": 2, "shapes": 5, "size": 2, "sort": 1, "std": 15, "string": 4, "template": 1, "throw": 1, "try": 1, "typename": 1, "unique_ptr": 1, "using": 1, "value": 2, "values": 2, "vector": 2, "virtual": 2, "what": 1, "world": 1, "{": 12, "}": 12, "~": 1}},
+		"CSS":        {Total: 229, Tokens: map[string]int{"!": 1, "#": 6, "%;": 1, "(": 5, ")": 5, "*": 1, "-": 22, "--": 4, ".": 7, ":": 28, "::": 1, ";": 24, "=": 1, ">": 1, "@": 2, "Arial": 1, "Helvetica": 1, "Neue": 1, "[": 1, "]": 1, "background": 1, "between": 1, "body": 1, "bold": 1, "border": 3, "bottom": 1, "box": 3, "card": 2, "color": 6, "content": 2, "css": 1, "ddd": 1, "decoration": 1, "display": 1, "ease": 1, "em": 1, "email": 1, "family": 1, "ff": 1, "fff": 1, "flex": 1, "focus": 1, "font": 5, "fonts": 1, "h1": 1, "header": 1, "hover": 1, "import": 1, "important": 1, "in": 1, "input": 1, "justify": 1, "list": 1, "margin": 2, "max": 1, "media": 1, "nav": 1, "none": 1, "out": 1, "padding": 2, "placeholder": 1, "position": 1, "primary": 2, "px": 6, "radius": 1, "relative": 1, "rem": 1, "rgba": 1, "root": 1, "sans": 1, "serif": 1, "shadow": 1, "site": 1, "size": 3, "sizing": 1, "solid": 1, "space": 1, "style": 1, "text": 1, "transform": 1, "transition": 1, "type": 1, "ul": 1, "underline": 1, "url": 1, "var": 2, "weight": 1, "width": 2, "{": 10, "}": 10}},
+		"Docker":     {Total: 221, Tokens: map[string]int{"&&": 4, "-": 18, "--": 5, ".": 12, "./": 2, "/": 28, "/*": 1, ":": 4, "://": 1, "=": 6, "=$": 1, "@": 1, "ADD": 1, "ARG": 1, "AS": 1, "CGO_ENABLED": 1, "CMD": 2, "COPY": 3, "ENTRYPOINT": 1, "ENV": 1, "EXPOSE": 1, "FROM": 3, "GOOS": 1, "HEALTHCHECK": 1, "LABEL": 1, "ONBUILD": 1, "RUN": 5, "SHELL": 1, "SIGTERM": 1, "STOPSIGNAL": 1, "USER": 1, "VERSION": 2, "VOLUME": 1, "WORKDIR": 1, "[": 4, "\\": 3, "]": 4, "add": 1, "addgroup": 1, "adduser": 1, "alpine": 2, "apk": 1, "app": 11, "apt": 3, "bash": 1, "bin": 3, "build": 1, "builder": 2, "building": 1, "ca": 1, "cache": 1, "certificates": 1, "cmd": 1, "com": 1, "config": 4, "data": 1, "dev": 1, "download": 1, "echo": 1, "etc": 2, "example": 1, "exit": 1, "from": 1, "get": 2, "go": 4, "golang": 1, "health": 1, "http": 1, "install": 2, "interval": 1, "jane": 1, "ldflags": 1, "lib": 1, "linux": 1, "lists": 1, "local": 2, "localhost": 1, "main": 1, "maintainer": 1, "mod": 2, "no": 2, "out": 2, "python3": 1, "qO": 1, "recommends": 1, "rf": 1, "rm": 1, "src": 1, "sum": 1, "tzdata": 1, "ubuntu": 1, "update": 1, "usr": 2, "var": 1, "version": 1, "wget": 1, "yaml": 3, "{": 1, "||": 1, "}": 1}},
+		"Go":         {Total: 664, Tokens: map[string]int{"!": 1, "!=": 4, "%": 2, "&": 1, "(": 68, ")": 68, "*": 10, "+": 2, "++": 1, "+=": 1, "-": 2, ".": 45, "...": 1, "/": 2, "//": 1, ":": 11, ":=": 17, ";": 6, "<": 2, "<-": 2, "<<": 1, "=": 7, "==": 2, ">": 1, "Add": 1, "Atoi": 1, "Client": 2, "Done": 1, "Err": 1, "ErrNotFound": 2, "Error": 1, "Errorf": 1, "Exit": 1, "Fprintf": 1, "Fprintln": 1, "Get": 1, "Handle": 2, "HandleFunc": 1, "Handler": 1, "Ints": 1, "KB": 1, "Len": 1, "Less": 1, "ListenAndServe": 1, "Lock": 1, "Logger": 1, "MB": 1, "Mutex": 1, "New": 1, "NewScanner": 1, "NewServer": 2, "Pop": 1, "Println": 1, "Push": 1, "Query": 1, "ReadNumbers": 1, "Reader": 1, "Request": 2, "ResponseWriter": 2, "Scan": 1, "ServeHTTP": 1, "Server": 4, "Stack": 3, "StatusNotFound": 1, "Stderr": 1, "Sum": 2, "Swap": 1, "Text": 1, "TrimSpace": 1, "URL": 1, "Unlock": 1, "WaitGroup": 1, "[": 20, "\\": 1, "]": 20, "add": 1, "addr": 2, "address": 1, "any": 1, "append": 2, "bool": 2, "bufio": 2, "byLength": 4, "byte": 1, "case": 2, "ch": 4, "chan": 1, "client": 1, "clients": 3, "close": 1, "const": 1, "ctx": 1, "data": 1, "default": 2, "defer": 1, "delete": 1, "done": 2, "empty": 1, "err": 12, "error": 2, "errors": 2, "fallthrough": 1, "false": 1, "fmt": 5, "for": 4, "found": 1, "func": 13, "go": 1, "goto": 1, "hello": 1, "http": 9, "if": 7, "import": 2, "init": 1, "int": 10, "interface": 2, "io": 2, "iota": 1, "item": 4, "items": 9, "len": 8, "log": 1, "logger": 1, "main": 2, "make": 2, "map": 3, "mu": 3, "name": 4, "nil": 10, "numbers": 8, "ok": 2, "os": 3, "package": 2, "range": 2, "return": 13, "scanner": 4, "sort": 2, "strconv": 2, "string": 5, "strings": 2, "struct": 2, "sync": 3, "the": 2, "total": 3, "type": 4, "var": 4, "wg": 2, "zero": 2, "{": 32, "}": 32}},
+		"HTML":       {Total: 281, Tokens: map[string]int{"-": 4, ".": 4, "/": 4, ";": 2, "<": 28, "<!": 1, "</": 22, "=": 25, ">": 49, ">&": 2, ">.<": 1, "><": 6, "></": 5, "About": 1, "DOCTYPE": 1, "Email": 1, "Example": 2, "Home": 1, "Logo": 1, "Name": 1, "Send": 1, "This": 1, "Value": 1, "Welcome": 1, "about": 1, "action": 1, "alt": 1, "an": 1, "app": 1, "body": 2, "br": 1, "button": 2, "card": 1, "charset": 1, "class": 2, "content": 2, "copy": 2, "css": 1, "defer": 1, "device": 1, "div": 2, "em": 2, "email": 4, "en": 1, "example": 1, "footer": 2, "for": 1, "form": 2, "h1": 2, "head": 2, "header": 3, "height": 1, "href": 3, "html": 4, "id": 2, "img": 1, "initial": 1, "input": 1, "is": 1, "js": 1, "label": 2, "lang": 1, "li": 4, "link": 1, "logo": 1, "main": 2, "meta": 2, "method": 1, "name": 2, "nav": 2, "page": 1, "paragraph": 1, "png": 1, "post": 1, "rel": 1, "required": 1, "scale": 1, "script": 2, "site": 1, "span": 2, "src": 2, "strong": 2, "style": 1, "stylesheet": 1, "submit": 2, "table": 2, "td": 2, "th": 2, "title": 2, "tr": 2, "type": 2, "ul": 2, "utf": 1, "viewport": 1, "width": 3, "with": 1}},
+		"Haskell":    {Total: 262, Tokens: map[string]int{"#-": 1, "$": 3, "(": 19, ")": 19, "*": 5, "+": 1, "++": 4, "-": 1, "-#": 1, "->": 10, ".": 12, "..": 1, "::": 6, "<-": 2, "=": 16, ">": 1, "Circle": 4, "Control": 1, "Counter": 2, "Data": 3, "Describable": 2, "Double": 4, "Eq": 1, "IO": 1, "Int": 5, "Integer": 2, "Just": 2, "LANGUAGE": 1, "List": 1, "Main": 1, "Map": 9, "Maybe": 2, "Monad": 1, "Nothing": 2, "OverloadedStrings": 1, "Rectangle": 4, "Shape": 3, "Show": 1, "String": 4, "[": 4, "\\": 2, "]": 4, "area": 4, "as": 1, "by": 1, "case": 1, "circle": 1, "class": 1, "contents": 2, "countWords": 3, "counts": 2, "data": 1, "deriving": 1, "describe": 4, "div": 1, "division": 1, "do": 1, "empty": 1, "even": 1, "factorial": 4, "foldl": 2, "forM_": 2, "fromMaybe": 2, "getContents": 1, "helper": 1, "id": 1, "import": 4, "insertWith": 1, "instance": 1, "let": 3, "lookup": 1, "main": 2, "map": 1, "module": 1, "newtype": 1, "of": 1, "pi": 1, "print": 4, "putStrLn": 2, "qualified": 1, "rectangle": 1, "safeDiv": 4, "shapes": 3, "show": 3, "sortBy": 1, "sum": 1, "the": 1, "total": 3, "when": 2, "where": 4, "words": 1, "zero": 1, "{": 1, "|": 2, "}": 1}},
+		"JSON":       {Total: 238, Tokens: map[string]int{"-": 4, "--": 1, ".": 19, ":": 59, "@": 3, "An": 1, "Antonette": 1, "Bret": 1, "Doe": 1, "Ervin": 1, "First": 1, "Graham": 1, "Gwenborough": 1, "Howell": 1, "Jane": 1, "Kulas": 1, "Leanne": 1, "Light": 1, "Plains": 1, "Second": 1, "Shanna": 1, "Sincere": 1, "Victor": 1, "Wisokyburgh": 1, "[": 5, "]": 5, "^": 3, "active": 2, "address": 2, "admin": 1, "app": 2, "application": 1, "april": 1, "author": 1, "biz": 1, "build": 1, "city": 2, "com": 1, "config": 1, "debug": 1, "dependencies": 1, "description": 1, "devDependencies": 1, "done": 2, "email": 3, "enabled": 1, "example": 4, "express": 1, "false": 3, "geo": 2, "id": 4, "index": 2, "items": 1, "jane": 1, "jest": 2, "js": 2, "keywords": 1, "lat": 2, "lng": 2, "lodash": 1, "main": 1, "manager": 2, "melissa": 1, "mode": 1, "name": 4, "node": 1, "null": 2, "port": 1, "private": 1, "production": 1, "ratio": 1, "score": 2, "scripts": 1, "start": 1, "street": 2, "tags": 3, "test": 1, "title": 2, "true": 4, "tv": 1, "user": 1, "username": 2, "version": 1, "webpack": 1, "{": 14, "}": 14}},
+		"Java":       {Total: 344, Tokens: map[string]int{"(": 30, ")": 30, "+": 5, "++": 1, ".": 30, ":": 2, ";": 27, "<": 5, "<>": 2, "=": 7, ">": 4, ">=": 1, "@": 2, "Animal": 4, "ArrayList": 2, "Comparable": 1, "Dog": 1, "Done": 1, "Exception": 1, "HashMap": 2, "IOException": 2, "Integer": 1, "List": 3, "MAX_SIZE": 2, "Main": 5, "Map": 2, "Override": 2, "RuntimeException": 1, "Shape": 1, "String": 8, "System": 3, "Woof": 1, "[": 1, "]": 1, "abstract": 2, "add": 1, "app": 1, "area": 1, "arg": 2, "args": 2, "boolean": 1, "catch": 1, "class": 3, "com": 1, "compareTo": 2, "count": 2, "counts": 6, "double": 1, "example": 1, "extends": 1, "final": 2, "finally": 1, "flush": 1, "for": 2, "get": 1, "getOrDefault": 1, "implements": 1, "import": 5, "int": 3, "interface": 1, "io": 1, "isFull": 1, "java": 5, "main": 3, "name": 3, "new": 4, "other": 2, "out": 3, "package": 1, "println": 2, "private": 3, "protected": 2, "public": 7, "put": 1, "return": 3, "size": 3, "speak": 2, "static": 2, "super": 1, "this": 1, "throw": 1, "throws": 1, "toLowerCase": 1, "toString": 1, "try": 1, "util": 4, "void": 4, "word": 3, "words": 7, "{": 17, "}": 17}},
+		"JavaScript": {Total: 733, Tokens: map[string]int{"!": 1, "!==": 2, "#": 1, "$": 2, "&&": 1, "(": 83, ")": 83, "*": 1, "+": 1, ".": 65, "...": 2, "/": 4, "/$": 1, "/:": 1, ":": 3, "://": 1, ";": 55, "<": 1, "</": 1, "=": 27, "===": 1, "=>": 14, ">": 2, ">=": 1, "?": 1, "??=": 1, "App": 1, "Cache": 2, "DOMContentLoaded": 1, "Error": 1, "HTTP": 1, "Hello": 1, "JSON": 1, "Map": 1, "Object": 1, "PORT": 4, "Promise": 1, "React": 1, "[": 4, "]": 4, "addEventListener": 3, "api": 2, "app": 5, "apply": 1, "args": 2, "async": 2, "await": 4, "button": 2, "cache": 3, "catch": 2, "class": 1, "clearTimeout": 1, "click": 1, "clicked": 1, "com": 1, "console": 7, "const": 17, "constructor": 1, "count": 1, "data": 2, "debounce": 3, "delete": 1, "document": 3, "el": 2, "entries": 8, "env": 1, "err": 3, "error": 5, "event": 2, "example": 2, "export": 2, "exports": 3, "express": 3, "fetch": 2, "filter": 1, "first": 2, "fn": 2, "for": 1, "forEach": 1, "from": 1, "fs": 3, "function": 5, "get": 5, "getElementById": 1, "has": 1, "https": 1, "id": 6, "if": 4, "import": 1, "innerHTML": 1, "innerWidth": 1, "item": 2, "items": 4, "json": 3, "key": 7, "keys": 1, "let": 3, "limit": 4, "listen": 1, "listening": 1, "load": 2, "loadConfig": 2, "log": 5, "map": 1, "message": 1, "meta": 2, "module": 4, "name": 2, "new": 4, "next": 1, "null": 3, "numbers": 2, "obj": 2, "of": 1, "ok": 1, "on": 1, "params": 1, "parse": 1, "path": 2, "preventDefault": 1, "process": 1, "querySelector": 1, "react": 1, "readFileSync": 1, "reduce": 1, "reject": 1, "req": 2, "require": 2, "res": 3, "resize": 1, "resolve": 2, "response": 6, "return": 7, "self": 1, "send": 1, "set": 3, "status": 2, "sum": 2, "this": 13, "timeout": 3, "undefined": 3, "url": 2, "useState": 2, "user": 5, "users": 2, "value": 5, "wait": 2, "window": 2, "{": 30, "}": 30}},
+		"Kotlin":     {Total: 318, Tokens: map[string]int{"!": 1, "$": 1, "(": 28, ")": 28, "->": 2, ".": 19, "..": 1, ":": 18, "<": 8, "=": 11, ">": 8, "?": 4, "?.": 1, "?:": 2, "Config": 1, "Failure": 2, "Hello": 1, "Int": 5, "Jane": 1, "Nothing": 1, "PORT": 1, "Repository": 2, "Result": 7, "String": 5, "Success": 3, "Throwable": 1, "User": 6, "UserRepository": 2, "[": 2, "]": 2, "class": 5, "com": 1, "const": 1, "coroutines": 2, "data": 3, "email": 1, "error": 2, "example": 1, "filter": 1, "findById": 3, "for": 1, "forEach": 1, "fun": 6, "greet": 2, "host": 1, "id": 5, "import": 2, "in": 1, "interface": 1, "is": 2, "isNotEmpty": 1, "it": 3, "item": 4, "kotlinx": 2, "lateinit": 1, "launch": 2, "listOf": 1, "main": 1, "map": 1, "message": 1, "mutableMapOf": 1, "name": 4, "names": 2, "none": 1, "null": 2, "object": 1, "out": 1, "override": 2, "package": 1, "println": 5, "private": 1, "repo": 3, "return": 1, "runBlocking": 2, "save": 3, "sealed": 1, "step": 1, "suspend": 2, "uppercase": 1, "user": 2, "users": 3, "val": 11, "value": 2, "var": 2, "when": 1, "who": 2, "world": 1, "{": 13, "}": 13}},
+		"Lua":        {Total: 251, Tokens: map[string]int{"%": 1, "(": 21, ")": 21, "+": 2, "+$": 1, "-": 1, "--": 1, ".": 13, "..": 1, ":": 6, "<": 2, "=": 18, "==": 1, ">": 3, "Account": 7, "CR": 1, "Files": 1, "[": 1, "]": 1, "^%": 1, "__index": 1, "acc": 3, "amount": 3, "api": 1, "balance": 8, "comment": 1, "config": 3, "count": 1, "do": 3, "elseif": 1, "enabled": 1, "end": 12, "err": 2, "error": 1, "false": 1, "for": 2, "function": 6, "funds": 1, "gsub": 2, "if": 3, "in": 2, "insufficient": 1, "ipairs": 1, "key": 2, "leader": 1, "list": 2, "local": 9, "names": 1, "new": 2, "nil": 3, "noremap": 1, "not": 1, "nvim_set_keymap": 1, "ok": 3, "opts": 5, "or": 3, "pairs": 1, "pcall": 1, "print": 3, "repeat": 1, "return": 6, "self": 6, "setmetatable": 1, "setup": 1, "then": 4, "trim": 2, "true": 3, "until": 1, "value": 2, "vim": 1, "while": 1, "width": 2, "withdraw": 2, "{": 7, "}": 7, "~=": 1}},
+		"Makefile":   {Total: 197, Tokens: map[string]int{"$": 20, "$<": 2, "$@": 2, "$^": 1, "%.": 6, "(": 21, ")": 21, "*.": 1, "+=": 3, "-": 11, "--": 1, ".": 2, "..": 1, "./.": 1, "/": 4, "/*.": 1, ":": 9, ":.": 1, ":=": 4, "=.": 1, "?=": 2, "@": 1, "BINDIR": 3, "CC": 2, "CFLAGS": 5, "DEBUG": 1, "DESTDIR": 2, "DNDEBUG": 1, "GO": 2, "GOFLAGS": 1, "LDFLAGS": 1, "O2": 1, "OBJECTS": 3, "PHONY": 1, "PREFIX": 2, "SOURCES": 2, "VERSION": 2, "Wall": 1, "all": 2, "app": 4, "bin": 1, "build": 4, "built": 1, "clean": 2, "config": 1, "describe": 1, "docs": 3, "echo": 1, "else": 1, "endif": 1, "export": 1, "for": 1, "git": 1, "go": 1, "html": 2, "ifeq": 1, "include": 1, "install": 4, "local": 1, "m755": 1, "md": 3, "mk": 1, "pandoc": 1, "patsubst": 1, "rm": 1, "shell": 1, "tags": 1, "test": 3, "usr": 1, "wildcard": 2}},
+		"PHP":        {Total: 323, Tokens: map[string]int{"$": 42, "(": 22, ")": 22, "*": 2, "->": 6, ".=": 1, "/": 1, ":": 5, "::": 3, ";": 24, "<": 2, "</": 1, "<?": 1, "=": 13, "===": 1, "=>": 5, ">": 3, "><?": 1, "?": 2, "?:": 1, "?>": 1, "?><": 1, "App": 2, "Controller": 1, "ENT_QUOTES": 1, "FETCH_ASSOC": 1, "FROM": 1, "Model": 1, "Not": 1, "PDO": 5, "SELECT": 1, "User": 1, "UserController": 3, "WHERE": 1, "[": 8, "\\": 3, "]": 8, "_GET": 2, "__construct": 1, "array": 2, "array_map": 1, "as": 1, "class": 1, "controller": 2, "db": 5, "declare": 1, "default": 2, "echo": 3, "execute": 1, "exit": 1, "fetch": 1, "fn": 1, "foreach": 1, "found": 1, "function": 4, "get_param": 2, "html": 3, "htmlspecialchars": 1, "http_response_code": 1, "id": 8, "if": 1, "int": 2, "isset": 1, "items": 3, "json_encode": 1, "key": 1, "li": 2, "memory": 1, "name": 7, "namespace": 1, "new": 2, "null": 3, "php": 1, "prepare": 1, "private": 1, "public": 3, "render": 2, "return": 3, "show": 2, "sqlite": 1, "static": 1, "stmt": 3, "strict_types": 1, "string": 2, "this": 2, "use": 2, "user": 10, "users": 3, "{": 8, "}": 8}},
+		"Perl":       {Total: 317, Tokens: map[string]int{"!": 1, "#!/": 1, "$": 37, "$!": 1, "%": 3, "%-": 1, "(": 14, ")": 14, "*#/": 1, "++;": 1, "+/": 1, "--": 1, "->": 2, "/": 5, "/\\": 1, "/^\\": 1, ":": 2, "::": 2, ";": 29, "<": 1, "<$": 1, "<=>": 1, "=": 7, "=>": 3, "=~": 2, ">": 1, "@": 6, "ARGV": 1, "Basename": 1, "Cannot": 1, "Counter": 1, "File": 1, "GetOptions": 1, "Getopt": 1, "Jane": 1, "Long": 1, "STDERR": 1, "[": 2, "\\": 7, "\\$": 1, "]": 2, "__END__": 1, "basename": 2, "bin": 1, "bless": 1, "chomp": 1, "class": 2, "close": 1, "counts": 6, "defined": 1, "die": 2, "fh": 3, "file": 5, "files": 5, "for": 2, "foreach": 1, "hello": 1, "if": 3, "keys": 1, "lc": 1, "line": 4, "local": 1, "log_msg": 2, "msg": 2, "my": 12, "name": 2, "new": 1, "next": 1, "no": 1, "open": 2, "or": 2, "package": 1, "perl": 1, "print": 4, "printf": 1, "push": 1, "qw": 2, "read": 1, "ref": 3, "return": 1, "scalar": 1, "shift": 1, "sort": 1, "split": 1, "strict": 1, "sub": 2, "tags": 2, "text": 4, "there": 1, "unless": 1, "usage": 1, "use": 4, "usr": 1, "verbose": 5, "warn": 1, "warnings": 1, "while": 1, "word": 5, "world": 2, "{": 17, "}": 17}},
+		"Python":     {Total: 619, Tokens: map[string]int{"!": 1, "#": 1, "%": 3, "(": 64, ")": 64, "*": 2, "**": 3, "+": 2, "+=": 1, "-": 2, "->": 2, ".": 37, "/": 2, ":": 48, "<": 1, "<=": 1, "=": 23, "==": 3, ">": 1, "@": 2, "ArgumentParser": 1, "Base": 2, "False": 1, "FileNotFoundError": 1, "Inventory": 3, "Keep": 1, "List": 2, "None": 6, "Optional": 2, "Sample": 2, "Statistics": 1, "True": 1, "ValueError": 1, "[": 7, "]": 7, "__init__": 3, "__main__": 2, "__name__": 2, "__repr__": 1, "__str__": 1, "_args": 2, "add": 2, "add_argument": 1, "argparse": 2, "args": 4, "argv": 3, "as": 4, "assert": 1, "async": 2, "await": 1, "class": 3, "collections": 1, "continue": 1, "count": 5, "counter": 1, "counts": 3, "data": 4, "dataclass": 2, "dataclasses": 1, "def": 14, "default_factory": 1, "defaultdict": 2, "del": 1, "description": 1, "done": 1, "elif": 1, "elif_test": 3, "else": 1, "empty": 1, "encoding": 1, "except": 1, "exit": 1, "fetch": 1, "fib": 2, "field": 2, "file": 1, "for": 8, "from": 3, "get": 1, "global": 1, "greet": 1, "hello": 1, "if": 7, "import": 8, "in": 10, "int": 2, "invalid": 1, "inventory": 3, "is": 1, "item": 4, "items": 5, "join": 1, "json": 2, "key": 2, "kwargs": 1, "lambda": 1, "lambda_fn": 1, "len": 3, "line": 8, "list": 3, "load": 3, "main": 3, "map": 1, "math": 2, "mean": 3, "name": 9, "not": 2, "object": 1, "of": 1, "ok": 1, "open": 2, "or": 2, "os": 1, "parse_args": 1, "parser": 4, "pass": 2, "path": 8, "print": 6, "property": 1, "raise": 1, "range": 1, "read_lines": 2, "response": 2, "return": 11, "round": 1, "sample": 3, "self": 22, "session": 2, "sqrt": 1, "squares": 2, "startswith": 1, "stddev": 1, "stderr": 1, "store": 1, "str": 5, "strip": 1, "sum": 3, "super": 1, "sys": 4, "text": 1, "the": 1, "total": 2, "track": 1, "url": 2, "value": 3, "values": 7, "with": 3, "yield": 2, "{": 4, "}": 4}},
+		"Ruby":       {Total: 253, Tokens: map[string]int{"#": 3, "%": 1, "&": 2, "&:": 2, "(": 13, ")": 13, "*": 1, ".": 20, ":": 5, "::": 2, "<<": 3, "=": 8, "=>": 1, ">": 1, "?": 3, "@": 10, "ArgumentError": 2, "Cart": 2, "Enumerable": 1, "File": 1, "JSON": 1, "Product": 2, "Shop": 3, "Total": 1, "[": 4, "]": 4, "apple": 1, "attr_accessor": 1, "attr_reader": 1, "banana": 1, "begin": 1, "block": 2, "cart": 6, "class": 2, "def": 8, "defined": 1, "do": 2, "done": 1, "each": 4, "end": 15, "ensure": 1, "fetch": 1, "from_hash": 2, "hash": 6, "helpers": 1, "if": 2, "include": 1, "initialize": 2, "inspect": 1, "json": 2, "map": 1, "message": 1, "module": 1, "name": 6, "names": 3, "new": 2, "nil": 1, "oops": 1, "parse": 1, "price": 6, "product": 4, "products": 7, "puts": 5, "quantity": 7, "raise": 1, "read": 1, "require": 1, "require_relative": 1, "rescue": 1, "select": 1, "self": 2, "sum": 1, "to_s": 1, "total": 6, "unless": 1, "upcase": 1, "zero": 1, "{": 4, "|": 6, "}": 4}},
+		"Rust":       {Total: 407, Tokens: map[string]int{"!": 6, "#": 1, "&": 5, "(": 35, ")": 35, "*": 4, "+=": 1, "->": 4, ".": 17, "..": 1, ":": 13, "::": 21, ":?": 1, ";": 13, "<": 5, "=": 7, "=>": 4, ">": 3, ">>": 1, "?;": 2, "BufRead": 1, "BufReader": 2, "Circle": 2, "Clone": 1, "Debug": 1, "Display": 1, "Err": 1, "File": 2, "Formatter": 1, "HashMap": 4, "Named": 1, "Ok": 2, "PI": 1, "PartialEq": 1, "Point": 5, "Result": 2, "Shape": 6, "Some": 1, "Square": 3, "String": 3, "Vec": 2, "[": 2, "]": 2, "area": 3, "center": 1, "collections": 1, "consts": 1, "count_words": 2, "counts": 5, "derive": 1, "entry": 1, "enum": 1, "eprintln": 1, "error": 1, "f64": 7, "file": 2, "first": 3, "fmt": 5, "fn": 5, "for": 3, "fs": 1, "if": 1, "impl": 2, "in": 2, "input": 1, "io": 2, "iter": 1, "let": 7, "line": 4, "lines": 1, "main": 1, "map": 1, "match": 2, "mut": 3, "name": 1, "new": 3, "open": 1, "or_insert": 1, "path": 2, "println": 3, "pub": 5, "push": 1, "radius": 4, "self": 7, "shapes": 2, "side": 3, "split_whitespace": 1, "std": 5, "str": 1, "struct": 1, "sum": 1, "to_lowercase": 1, "total": 3, "trait": 1, "txt": 1, "u32": 1, "use": 4, "usize": 2, "vec": 1, "word": 2, "write": 1, "{": 24, "|": 2, "}": 24}},
+		"SQL":        {Total: 202, Tokens: map[string]int{"%@": 1, "(": 13, ")": 13, "*": 2, "-": 2, ".": 11, ";": 12, "=": 3, ">": 2, "@": 1, "ADD": 1, "ALTER": 1, "AS": 2, "BEGIN": 1, "BOOLEAN": 1, "BY": 2, "CASCADE": 1, "COLUMN": 1, "COMMIT": 1, "COUNT": 2, "CREATE": 3, "CURRENT_TIMESTAMP": 1, "DECIMAL": 1, "DEFAULT": 3, "DELETE": 2, "DESC": 1, "DISTINCT": 1, "DROP": 1, "EXISTS": 1, "FROM": 4, "GROUP": 1, "HAVING": 1, "IF": 1, "IN": 2, "INDEX": 1, "INSERT": 1, "INTEGER": 2, "INTO": 1, "IS": 1, "JOIN": 1, "Jane": 1, "KEY": 2, "LEFT": 1, "LIMIT": 1, "NOT": 4, "NULL": 4, "ON": 3, "ORDER": 1, "PRIMARY": 2, "REFERENCES": 1, "SELECT": 3, "SERIAL": 1, "SET": 1, "SUM": 1, "TABLE": 4, "TEXT": 1, "TIMESTAMP": 1, "TRUE": 1, "UNIQUE": 1, "UPDATE": 1, "VALUES": 1, "VARCHAR": 1, "WHERE": 4, "active": 2, "and": 1, "com": 2, "created_at": 2, "email": 3, "example": 2, "from": 1, "id": 8, "idx_orders_user": 1, "jane": 1, "like": 1, "name": 5, "order_count": 1, "orders": 5, "select": 1, "sessions": 1, "total": 6, "true": 1, "user_id": 5, "users": 8, "where": 1}},
+		"Swift":      {Total: 283, Tokens: map[string]int{"!": 1, "$": 1, "(": 21, ")": 21, "*": 3, "+": 1, "->": 4, ".": 11, ":": 17, "=": 8, "==": 1, "?": 1, "@": 1, "Array": 1, "Circle": 3, "Data": 1, "Double": 4, "Element": 1, "Error": 1, "Foundation": 1, "Hello": 2, "IBOutlet": 1, "Int": 3, "NetworkError": 2, "No": 1, "Shape": 3, "String": 5, "UIKit": 1, "UILabel": 1, "UIViewController": 1, "URL": 1, "URLSession": 1, "ViewController": 1, "[": 5, "\\": 3, "]": 5, "area": 2, "async": 1, "await": 1, "badURL": 2, "case": 3, "class": 1, "count": 2, "data": 3, "default": 1, "describe": 3, "doubled": 3, "else": 2, "empty": 1, "enum": 1, "extension": 1, "final": 1, "for": 1, "from": 2, "func": 5, "get": 1, "guard": 1, "if": 1, "import": 2, "in": 1, "items": 2, "label": 2, "let": 6, "load": 1, "map": 1, "name": 3, "nil": 1, "optionalName": 2, "override": 1, "pi": 1, "print": 5, "private": 1, "protocol": 1, "radius": 6, "reduce": 1, "return": 4, "seconds": 1, "shape": 2, "shapes": 2, "shared": 1, "string": 1, "struct": 1, "sum": 1, "super": 1, "switch": 1, "text": 1, "throw": 1, "throws": 1, "timeout": 1, "try": 1, "url": 2, "urlString": 2, "var": 5, "viewDidLoad": 2, "weak": 1, "where": 1, "with": 1, "{": 17, "}": 17}},
+		"TOML":       {Total: 172, Tokens: map[string]int{"-": 6, ".": 19, "/": 1, ":": 2, "://": 2, "<": 1, "=": 29, ">": 1, ">=": 1, "@": 1, "Doe": 1, "Jane": 1, "MIT": 1, "T00": 1, "[": 16, "]": 16, "^": 1, "authors": 1, "backend": 1, "bin": 1, "build": 2, "build_meta": 1, "com": 1, "created": 1, "criterion": 1, "database": 1, "debug": 1, "dependencies": 3, "derive": 1, "description": 1, "dev": 1, "edition": 1, "enabled": 1, "example": 3, "false": 1, "features": 2, "full": 1, "host": 1, "jane": 1, "level": 1, "license": 1, "lto": 1, "main": 1, "name": 3, "opt": 1, "package": 1, "path": 1, "poetry": 2, "port": 1, "postgres": 2, "profile": 1, "project": 2, "python": 1, "regex": 1, "release": 1, "replica": 1, "requires": 1, "rs": 1, "serde": 1, "server": 1, "setuptools": 2, "src": 1, "system": 1, "timeout": 1, "tokio": 1, "tool": 2, "true": 2, "urls": 1, "version": 3, "{": 2, "}": 2}},
+		"TypeScript": {Total: 337, Tokens: map[string]int{"!": 1, "$": 1, "(": 15, ")": 15, ".": 10, "/": 1, ":": 30, ";": 24, "<": 7, "=": 10, ">": 5, ">>": 1, "?:": 1, "@": 2, "Active": 2, "Array": 1, "Disabled": 1, "HttpClient": 1, "Injectable": 2, "Map": 2, "Number": 1, "Object": 1, "Promise": 1, "Record": 1, "Request": 2, "Response": 2, "Result": 2, "Shape": 1, "Status": 3, "User": 6, "UserService": 2, "[": 3, "]": 3, "abstract": 2, "active": 1, "add": 1, "angular": 1, "area": 1, "as": 2, "async": 1, "class": 2, "config": 1, "const": 6, "constructor": 1, "core": 1, "count": 2, "declare": 1, "default": 1, "disabled": 1, "email": 1, "enum": 1, "error": 2, "export": 7, "express": 1, "false": 2, "find": 1, "from": 2, "function": 2, "get": 1, "handler": 1, "http": 1, "id": 8, "identity": 1, "if": 1, "import": 2, "interface": 1, "json": 1, "keyof": 1, "keys": 2, "let": 1, "module": 1, "name": 1, "names": 2, "new": 1, "no": 1, "number": 7, "ok": 4, "params": 1, "port": 1, "private": 2, "providedIn": 1, "public": 1, "readonly": 3, "req": 2, "res": 2, "return": 3, "roles": 1, "root": 1, "set": 1, "status": 2, "string": 6, "this": 2, "true": 2, "type": 2, "undefined": 1, "unknown": 1, "user": 7, "users": 3, "value": 4, "void": 2, "{": 21, "|": 2, "}": 21}},
+		"YAML":       {Total: 319, Tokens: map[string]int{"#": 1, "&": 1, "*": 1, "-": 19, "---": 1, ".": 7, "./": 2, "/": 7, ":": 99, ":/": 1, "://": 1, "<<:": 1, "@": 2, "Application": 1, "Configure": 1, "DATABASE_URL": 1, "DEBUG": 1, "Deployment": 1, "Dockerfile": 1, "Install": 1, "POSTGRES_PASSWORD": 1, "POSTGRES_USER": 1, "Run": 1, "Start": 1, "[": 2, "]": 2, "actions": 1, "always": 1, "anchors": 1, "api": 3, "apiVersion": 1, "app": 3, "apps": 1, "apt": 1, "become": 1, "branches": 1, "build": 1, "checkout": 1, "containers": 1, "context": 1, "database": 1, "db": 2, "defaults": 2, "depends_on": 1, "description": 2, "dockerfile": 1, "driver": 1, "enabled": 2, "environment": 2, "example": 2, "false": 1, "file": 1, "go": 1, "handlers": 1, "host": 1, "hosts": 1, "html": 2, "http_port": 1, "image": 3, "info": 1, "items": 1, "jobs": 1, "kind": 1, "labels": 1, "latest": 2, "level": 1, "line": 1, "logging": 1, "main": 1, "make": 1, "max": 1, "max_clients": 1, "metadata": 1, "min": 1, "multi": 1, "name": 11, "nginx": 8, "null": 1, "on": 2, "one": 1, "other": 1, "outputs": 1, "password": 1, "pool": 1, "port": 1, "ports": 2, "postgres": 3, "present": 1, "pull_request": 1, "push": 1, "replicas": 1, "restart": 2, "restarted": 1, "retries": 1, "ro": 1, "run": 1, "runs": 1, "secret": 2, "server": 1, "servers": 1, "service": 2, "services": 1, "settings": 1, "share": 1, "spec": 2, "started": 1, "state": 3, "stdout": 1, "steps": 1, "tags": 1, "tasks": 1, "template": 1, "test": 2, "tests": 1, "three": 1, "timeout": 1, "true": 3, "two": 1, "ubuntu": 1, "update_cache": 1, "user": 2, "uses": 1, "usr": 1, "v1": 1, "v4": 1, "vars": 1, "version": 2, "volumes": 1, "web": 6, "webservers": 1, "with": 1, "yes": 1, "{": 1, "|": 1, "}": 1, "~": 1}},
+		"plaintext":  {Total: 452, Tokens: map[string]int{".": 25, "Anna": 1, "As": 1, "Best": 1, "Dear": 1, "Finally": 1, "First": 1, "Have": 1, "He": 3, "If": 1, "It": 3, "June": 1, "Most": 1, "Second": 1, "She": 2, "Thank": 1, "The": 2, "Then": 1, "They": 1, "We": 2, "When": 1, "about": 5, "afternoon": 1, "again": 2, "ago": 1, "air": 1, "all": 1, "almost": 1, "along": 1, "an": 1, "and": 13, "any": 1, "anything": 1, "apart": 1, "around": 1, "as": 2, "asked": 1, "at": 1, "away": 1, "back": 1, "be": 4, "been": 1, "before": 2, "behind": 1, "belonged": 1, "below": 1, "bench": 2, "birds": 1, "boy": 1, "but": 2, "by": 3, "came": 2, "can": 1, "children": 1, "chimneys": 1, "city": 1, "clouds": 1, "come": 1, "coming": 1, "could": 2, "dark": 1, "date": 1, "day": 1, "did": 1, "does": 1, "dog": 1, "down": 1, "eat": 1, "edge": 1, "empty": 1, "end": 1, "evening": 1, "every": 1, "except": 1, "falling": 1, "family": 1, "far": 1, "father": 1, "few": 1, "fields": 1, "finally": 1, "for": 7, "forest": 1, "friends": 1, "from": 2, "garden": 1, "gate": 1, "go": 1, "gone": 1, "good": 1, "got": 1, "grandmother": 1, "had": 5, "happy": 1, "has": 1, "have": 4, "he": 1, "hear": 1, "held": 1, "help": 1, "her": 6, "here": 1, "hill": 1, "him": 1, "his": 1, "in": 6, "is": 2, "it": 3, "know": 2, "long": 2, "many": 2, "meet": 2, "more": 2, "nice": 2, "nobody": 2, "not": 3, "of": 8, "old": 3, "on": 2, "said": 2, "see": 3, "she": 10, "since": 2, "so": 3, "sun": 2, "talked": 2, "that": 7, "the": 38, "them": 2, "there": 3, "this": 2, "time": 2, "to": 13, "told": 2, "until": 2, "up": 2, "was": 10, "we": 5, "week": 3, "went": 2, "were": 2, "what": 2, "when": 2, "where": 3, "while": 2, "who": 2, "will": 3, "with": 4, "would": 2, "you": 6}},
+	},
+}
@@ -0,0 +1,58 @@
+#!/bin/bash
+set -euo pipefail
+
+VERSION="${VERSION:-1.0.0}"
+BUILD_DIR="$(pwd)/build"
+OUTPUT=$BUILD_DIR/app
+
+usage() {
+    echo "Usage: $0 [-v] [-o output] target..." >&2
+    exit 1
+}
+
+log() {
+    if [ "$VERBOSE" = "true" ]; then
+        echo "[$(date +%H:%M:%S)] $*"
+    fi
+}
+
+while getopts "vo:" opt; do
+    case $opt in
+        v) VERBOSE=true ;;
+        o) OUTPUT="$OPTARG" ;;
+        *) usage ;;
+    esac
+done
+shift $((OPTIND - 1))
+
+mkdir -p "$BUILD_DIR"
+cd "$BUILD_DIR" || exit 1
+
+for target in "$@"; do
+    log "building $target"
+    if [[ -f "$target.tar.gz" ]]; then
+        rm -f "$target.tar.gz"
+    elif [ -d "$target" ]; then
+        tar czf "$target.tar.gz" "$target"
+    fi
+done
+
+export PATH="$HOME/bin:$PATH"
+files=$(find . -name '*.sh' -type f | sort)
+count=0
+for f in $files; do
+    count=$((count + 1))
+done
+
+if ! command -v git > /dev/null 2>&1; then
+    echo "git not found" 1>&2
+    exit 1
+fi
+
+cat <<END > version.txt
+version=$VERSION
+END
+
+sudo apt-get install -y curl && curl -fsSL https://example.com/install.sh | sh
+ls -la | grep -v '^total' | awk '{print $9}'
+echo "done: $count files"
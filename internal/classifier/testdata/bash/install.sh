@@ -0,0 +1,44 @@
+#!/bin/sh
+# Install the application
+
+if [ "$(id -u)" -ne 0 ]; then
+  echo "Please run as root" >&2
+  exit 1
+fi
+
+DEST=/opt/app
+mkdir -p "$DEST"
+cp -r ./dist/* "$DEST"/
+chmod +x "$DEST/bin/app"
+ln -sf "$DEST/bin/app" /usr/local/bin/app
+
+echo "$HOME"
+if [ -f foo ]; then
+  rm -f foo
+fi
+
+read -r -p "Start the service now? [y/N] " answer
+case "$answer" in
+  [yY]*) systemctl enable --now app.service ;;
+  *) echo "Skipping" ;;
+esac
+
+for i in 1 2 3; do
+  echo "attempt $i"
+  sleep 1
+done
+
+until curl -s http://localhost:8080 > /dev/null; do
+  sleep 2
+done
+
+source ~/.bashrc
+alias ll='ls -l'
+trap 'rm -rf "$TMPDIR"' EXIT
+function cleanup {
+  local dir=$1
+  [ -n "$dir" ] && rm -rf "$dir"
+  return 0
+}
+git clone https://github.com/example/app.git && cd app && make install
+export EDITOR=vim
@@ -0,0 +1,59 @@
+#include <assert.h>
+#include <stdint.h>
+#include "list.h"
+
+struct list {
+	size_t len;
+	size_t cap;
+	int *items;
+};
+
+struct list *list_new(size_t cap)
+{
+	struct list *l = calloc(1, sizeof(*l));
+	if (!l)
+		return NULL;
+	l->items = calloc(cap, sizeof(int));
+	l->cap = cap;
+	return l;
+}
+
+int list_append(struct list *l, int value)
+{
+	if (l->len == l->cap) {
+		size_t cap = l->cap * 2;
+		int *items = realloc(l->items, cap * sizeof(int));
+		if (items == NULL)
+			return -1;
+		l->items = items;
+		l->cap = cap;
+	}
+	l->items[l->len++] = value;
+	return 0;
+}
+
+void list_free(struct list *l)
+{
+	free(l->items);
+	free(l);
+}
+
+static inline uint32_t hash(const char *s)
+{
+	uint32_t h = 5381;
+	while (*s)
+		h = ((h << 5) + h) + (unsigned char)*s++;
+	return h;
+}
+
+enum color { RED, GREEN, BLUE };
+
+void print_all(const struct list *l)
+{
+	for (size_t i = 0; i < l->len; i++)
+		printf("%d\n", l->items[i]);
+	memset((void *)0, 0, 0);
+	goto out;
+out:
+	return;
+}
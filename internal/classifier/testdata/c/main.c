@@ -0,0 +1,63 @@
+#include <stdio.h>
+#include <stdlib.h>
+#include <string.h>
+
+#define BUFFER_SIZE 1024
+#define MAX(a, b) ((a) > (b) ? (a) : (b))
+
+typedef struct node {
+    int value;
+    struct node *next;
+} node_t;
+
+static node_t *push(node_t *head, int value)
+{
+    node_t *n = malloc(sizeof(node_t));
+    if (n == NULL) {
+        perror("malloc");
+        exit(EXIT_FAILURE);
+    }
+    n->value = value;
+    n->next = head;
+    return n;
+}
+
+static void free_list(node_t *head)
+{
+    while (head != NULL) {
+        node_t *next = head->next;
+        free(head);
+        head = next;
+    }
+}
+
+int main(int argc, char **argv)
+{
+    char buffer[BUFFER_SIZE];
+    node_t *list = NULL;
+    FILE *fp;
+
+    if (argc < 2) {
+        fprintf(stderr, "usage: %s file\n", argv[0]);
+        return 1;
+    }
+    fp = fopen(argv[1], "r");
+    if (!fp) {
+        return 1;
+    }
+    while (fgets(buffer, sizeof(buffer), fp) != NULL) {
+        size_t len = strlen(buffer);
+        unsigned int i;
+        for (i = 0; i < len; i++) {
+            list = push(list, (int)buffer[i]);
+        }
+    }
+    fclose(fp);
+    printf("%d\n", MAX(1, 2));
+    free_list(list);
+    return 0;
+}
+
+#ifdef DEBUG
+const char *version = "debug";
+#endif
@@ -0,0 +1,56 @@
+#include <iostream>
+#include <memory>
+#include <string>
+#include <vector>
+#include <algorithm>
+
+namespace geometry {
+
+class Shape {
+public:
+    virtual ~Shape() = default;
+    virtual double area() const = 0;
+};
+
+class Circle : public Shape {
+public:
+    explicit Circle(double radius) : radius_(radius) {}
+    double area() const override { return 3.14159 * radius_ * radius_; }
+
+private:
+    double radius_;
+};
+
+template <typename T>
+T clamp(const T& value, const T& low, const T& high) {
+    return std::max(low, std::min(value, high));
+}
+
+}  // namespace geometry
+
+using namespace std;
+
+int main() {
+    std::vector<std::unique_ptr<geometry::Shape>> shapes;
+    shapes.push_back(std::make_unique<geometry::Circle>(2.0));
+    for (const auto& shape : shapes) {
+        std::cout << "area: " << shape->area() << std::endl;
+    }
+    std::string name = "world";
+    auto greet = [&name](const std::string& greeting) -> std::string {
+        return greeting + ", " + name;
+    };
+    cout << greet("hello") << endl;
+    std::sort(shapes.begin(), shapes.end(), [](const auto& a, const auto& b) {
+        return a->area() < b->area();
+    });
+    constexpr int size = 10;
+    int* values = new int[size];
+    delete[] values;
+    try {
+        throw std::runtime_error("oops");
+    } catch (const std::exception& e) {
+        std::cerr << e.what() << '\n';
+    }
+    return 0;
+}
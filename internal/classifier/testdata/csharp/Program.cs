@@ -0,0 +1,61 @@
+using System;
+using System.Collections.Generic;
+using System.Linq;
+using System.Threading.Tasks;
+
+namespace Example.App
+{
+    public interface IRepository<T>
+    {
+        Task<T> GetAsync(int id);
+    }
+
+    public class Customer
+    {
+        public int Id { get; set; }
+        public string Name { get; set; } = string.Empty;
+        public List<Order> Orders { get; } = new List<Order>();
+    }
+
+    public sealed class CustomerRepository : IRepository<Customer>
+    {
+        private readonly Dictionary<int, Customer> _customers = new();
+
+        public async Task<Customer> GetAsync(int id)
+        {
+            await Task.Delay(10);
+            if (!_customers.TryGetValue(id, out var customer))
+            {
+                throw new KeyNotFoundException($"No customer {id}");
+            }
+            return customer;
+        }
+    }
+
+    internal static class Program
+    {
+        public static async Task Main(string[] args)
+        {
+            var repository = new CustomerRepository();
+            var names = args.Where(a => !string.IsNullOrEmpty(a))
+                            .Select(a => a.ToUpper())
+                            .ToList();
+            foreach (var name in names)
+            {
+                Console.WriteLine($"Hello, {name}!");
+            }
+            try
+            {
+                var customer = await repository.GetAsync(1);
+                Console.WriteLine(customer.Name);
+            }
+            catch (KeyNotFoundException ex)
+            {
+                Console.Error.WriteLine(ex.Message);
+            }
+        }
+    }
+
+    [Serializable]
+    public record Order(int Id, decimal Total);
+}
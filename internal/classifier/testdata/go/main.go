@@ -0,0 +1,72 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+)
+
+type Server struct {
+	mu      sync.Mutex
+	clients map[string]*Client
+	logger  *log.Logger
+}
+
+type Handler interface {
+	ServeHTTP(w http.ResponseWriter, r *http.Request)
+}
+
+var ErrNotFound = errors.New("not found")
+
+func NewServer(addr string) (*Server, error) {
+	if addr == "" {
+		return nil, fmt.Errorf("empty address: %w", ErrNotFound)
+	}
+	s := &Server{clients: make(map[string]*Client)}
+	return s, nil
+}
+
+func (s *Server) Handle(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	name := strings.TrimSpace(r.URL.Query().Get("name"))
+	if _, ok := s.clients[name]; !ok {
+		http.Error(w, "unknown client", http.StatusNotFound)
+		return
+	}
+	fmt.Fprintf(w, "hello %s\n", name)
+}
+
+func main() {
+	ch := make(chan int, 10)
+	go func() {
+		for i := 0; i < 10; i++ {
+			ch <- i
+		}
+		close(ch)
+	}()
+	for v := range ch {
+		fmt.Println(v)
+	}
+	s, err := NewServer(":8080")
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	http.HandleFunc("/", s.Handle)
+	if err := http.ListenAndServe(":8080", nil); err != nil {
+		panic(err)
+	}
+	var wg sync.WaitGroup
+	wg.Add(1)
+	select {
+	case <-ctx.Done():
+		return
+	default:
+	}
+	x := []byte("data")
+	_ = len(x)
+}
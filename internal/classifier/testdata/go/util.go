@@ -0,0 +1,79 @@
+package util
+
+import (
+	"bufio"
+	"io"
+	"sort"
+	"strconv"
+)
+
+// Sum returns the sum of the numbers
+func Sum(numbers ...int) int {
+	total := 0
+	for _, n := range numbers {
+		total += n
+	}
+	return total
+}
+
+func add(a, b int) int {
+	return a + b
+}
+
+type byLength []string
+
+func (s byLength) Len() int           { return len(s) }
+func (s byLength) Less(i, j int) bool { return len(s[i]) < len(s[j]) }
+func (s byLength) Swap(i, j int)      { s[i], s[j] = s[j], s[i] }
+
+func ReadNumbers(r io.Reader) ([]int, error) {
+	var numbers []int
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		n, err := strconv.Atoi(scanner.Text())
+		if err != nil {
+			return nil, err
+		}
+		numbers = append(numbers, n)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	sort.Ints(numbers)
+	return numbers, nil
+}
+
+type Stack[T any] struct {
+	items []T
+}
+
+func (s *Stack[T]) Push(item T) {
+	s.items = append(s.items, item)
+}
+
+func (s *Stack[T]) Pop() (T, bool) {
+	var zero T
+	if len(s.items) == 0 {
+		return zero, false
+	}
+	item := s.items[len(s.items)-1]
+	s.items = s.items[:len(s.items)-1]
+	return item, true
+}
+
+const (
+	KB = 1 << (10 * (iota + 1))
+	MB
+)
+
+func init() {
+	m := map[string]interface{}{"a": 1}
+	delete(m, "a")
+	switch x := len(m); {
+	case x > 0:
+		fallthrough
+	default:
+		goto done
+	}
+done:
+}
@@ -0,0 +1,50 @@
+{-# LANGUAGE OverloadedStrings #-}
+module Main where
+
+import qualified Data.Map as Map
+import Data.List (sortBy, foldl')
+import Data.Maybe (fromMaybe)
+import Control.Monad (forM_, when)
+
+data Shape = Circle Double | Rectangle Double Double
+  deriving (Show, Eq)
+
+class Describable a where
+  describe :: a -> String
+
+instance Describable Shape where
+  describe (Circle r) = "circle " ++ show r
+  describe (Rectangle w h) = "rectangle " ++ show w ++ "x" ++ show h
+
+area :: Shape -> Double
+area (Circle r) = pi * r * r
+area (Rectangle w h) = w * h
+
+newtype Counter = Counter (Map.Map String Int)
+
+countWords :: [String] -> Map.Map String Int
+countWords = foldl' (\m w -> Map.insertWith (+) w 1 m) Map.empty
+
+factorial :: Integer -> Integer
+factorial 0 = 1
+factorial n = n * factorial (n - 1)
+
+safeDiv :: Int -> Int -> Maybe Int
+safeDiv _ 0 = Nothing
+safeDiv x y = Just (x `div` y)
+
+main :: IO ()
+main = do
+  let shapes = [Circle 1.0, Rectangle 2.0 3.0]
+  forM_ shapes $ \s -> putStrLn (describe s)
+  let total = sum (map area shapes)
+  when (total > 1) $ print total
+  contents <- getContents
+  let counts = countWords (words contents)
+  print $ fromMaybe 0 (Map.lookup "the" counts)
+  print [x * 2 | x <- [1..10], even x]
+  case safeDiv 10 2 of
+    Just v -> print v
+    Nothing -> putStrLn "division by zero"
+  where
+    helper = id
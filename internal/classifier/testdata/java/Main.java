@@ -0,0 +1,69 @@
+package com.example.app;
+
+import java.io.IOException;
+import java.util.ArrayList;
+import java.util.HashMap;
+import java.util.List;
+import java.util.Map;
+
+public class Main {
+    private static final int MAX_SIZE = 100;
+    private final Map<String, Integer> counts = new HashMap<>();
+
+    public Main() {
+        super();
+    }
+
+    public static void main(String[] args) throws IOException {
+        Main main = new Main();
+        List<String> words = new ArrayList<>();
+        for (String arg : args) {
+            words.add(arg.toLowerCase());
+        }
+        main.count(words);
+        System.out.println("Done: " + words.size());
+    }
+
+    @Override
+    public String toString() {
+        return "Main{" + "counts=" + counts + '}';
+    }
+
+    protected void count(List<String> words) {
+        for (int i = 0; i < words.size(); i++) {
+            String word = words.get(i);
+            counts.put(word, counts.getOrDefault(word, 0) + 1);
+        }
+    }
+
+    private boolean isFull() {
+        return counts.size() >= MAX_SIZE;
+    }
+}
+
+interface Shape {
+    double area();
+}
+
+abstract class Animal implements Comparable<Animal> {
+    protected String name;
+
+    public abstract void speak();
+
+    @Override
+    public int compareTo(Animal other) {
+        return this.name.compareTo(other.name);
+    }
+}
+
+class Dog extends Animal {
+    public void speak() {
+        try {
+            System.out.println("Woof");
+        } catch (Exception e) {
+            throw new RuntimeException(e);
+        } finally {
+            System.out.flush();
+        }
+    }
+}
@@ -0,0 +1,60 @@
+const express = require('express');
+const fs = require('fs');
+
+const app = express();
+const PORT = process.env.PORT || 3000;
+
+function loadConfig(path) {
+  return JSON.parse(fs.readFileSync(path, 'utf8'));
+}
+
+class Cache {
+  constructor(limit) {
+    this.limit = limit;
+    this.entries = new Map();
+  }
+
+  get(key) {
+    return this.entries.has(key) ? this.entries.get(key) : undefined;
+  }
+
+  set(key, value) {
+    if (this.entries.size >= this.limit) {
+      const first = this.entries.keys().next().value;
+      this.entries.delete(first);
+    }
+    this.entries.set(key, value);
+  }
+}
+
+const cache = new Cache(100);
+
+app.get('/users/:id', async (req, res) => {
+  const id = req.params.id;
+  let user = cache.get(id);
+  if (user === undefined) {
+    try {
+      const response = await fetch(`https://api.example.com/users/${id}`);
+      user = await response.json();
+      cache.set(id, user);
+    } catch (err) {
+      console.error(err);
+      return res.status(500).send({ error: err.message });
+    }
+  }
+  res.json(user);
+});
+
+document.addEventListener('DOMContentLoaded', () => {
+  const button = document.querySelector('#submit');
+  button.addEventListener('click', (event) => {
+    event.preventDefault();
+    console.log('clicked', this);
+  });
+});
+
+const items = [1, 2, 3].map((x) => x * 2).filter((x) => x !== null);
+var self = this;
+module.exports = { app, loadConfig };
+
+app.listen(PORT, () => console.log(`listening on ${PORT}`));
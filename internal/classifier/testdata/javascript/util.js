@@ -0,0 +1,53 @@
+'use strict';
+
+export function debounce(fn, wait = 100) {
+  let timeout;
+  return function (...args) {
+    clearTimeout(timeout);
+    timeout = setTimeout(() => fn.apply(this, args), wait);
+  };
+}
+
+export const sum = (numbers) => numbers.reduce((a, b) => a + b, 0);
+
+const el = document.getElementById('app');
+el.innerHTML = '<p>Hello</p>';
+window.addEventListener('resize', debounce(() => {
+  console.log(window.innerWidth);
+}));
+
+async function load(url) {
+  const response = await fetch(url);
+  if (!response.ok) {
+    throw new Error(`HTTP ${response.status}`);
+  }
+  const { data, meta } = await response.json();
+  return [...data, meta];
+}
+
+load('/api/items')
+  .then((items) => items.forEach((item) => console.log(item)))
+  .catch((error) => console.error(error));
+
+const obj = {
+  name: 'example',
+  get upper() {
+    return this.name.toUpperCase();
+  },
+};
+
+for (const [key, value] of Object.entries(obj)) {
+  console.log(key, value);
+}
+
+if (typeof module !== 'undefined' && module.exports) {
+  module.exports = { debounce, sum };
+}
+let x = null;
+x ??= 1;
+new Promise((resolve, reject) => resolve(x));
+import React, { useState } from 'react';
+function App() {
+  const [count, setCount] = useState(0);
+  return null;
+}
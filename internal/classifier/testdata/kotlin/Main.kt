@@ -0,0 +1,54 @@
+package com.example
+
+import kotlinx.coroutines.launch
+import kotlinx.coroutines.runBlocking
+
+data class User(val id: Int, val name: String, var email: String? = null)
+
+sealed class Result<out T> {
+    data class Success<T>(val value: T) : Result<T>()
+    data class Failure(val error: Throwable) : Result<Nothing>()
+}
+
+interface Repository<T> {
+    fun findById(id: Int): T?
+    suspend fun save(item: T)
+}
+
+class UserRepository : Repository<User> {
+    private val users = mutableMapOf<Int, User>()
+
+    override fun findById(id: Int): User? = users[id]
+
+    override suspend fun save(item: User) {
+        users[item.id] = item
+    }
+}
+
+object Config {
+    const val PORT = 8080
+    lateinit var host: String
+}
+
+fun greet(name: String?): String {
+    val who = name ?: "world"
+    return "Hello, $who!"
+}
+
+fun main() = runBlocking {
+    val repo = UserRepository()
+    launch {
+        repo.save(User(1, "Jane"))
+    }
+    val names = listOf("a", "b", "c").map { it.uppercase() }.filter { it.isNotEmpty() }
+    names.forEach { println(it) }
+    when (val r: Result<Int> = Result.Success(42)) {
+        is Result.Success -> println(r.value)
+        is Result.Failure -> println(r.error.message)
+    }
+    val user = repo.findById(1)
+    println(user?.name ?: "none")
+    for (i in 1..10 step 2) {
+        println(greet(null))
+    }
+}
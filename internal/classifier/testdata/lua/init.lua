@@ -0,0 +1,57 @@
+local M = {}
+
+local function trim(s)
+  return (s:gsub("^%s+", ""):gsub("%s+$", ""))
+end
+
+function M.setup(opts)
+  opts = opts or {}
+  local config = {
+    enabled = true,
+    width = opts.width or 80,
+    names = {},
+  }
+  for key, value in pairs(opts) do
+    config[key] = value
+  end
+  return config
+end
+
+function M.count(list)
+  local n = 0
+  for i, v in ipairs(list) do
+    if v ~= nil then
+      n = n + 1
+    end
+  end
+  return n
+end
+
+local Account = {}
+Account.__index = Account
+
+function Account.new(balance)
+  local self = setmetatable({}, Account)
+  self.balance = balance or 0
+  return self
+end
+
+function Account:withdraw(amount)
+  if amount > self.balance then
+    error("insufficient funds")
+  end
+  self.balance = self.balance - amount
+end
+
+local acc = Account.new(100)
+acc:withdraw(10)
+print("balance: " .. acc.balance)
+
+vim.api.nvim_set_keymap("n", "<leader>f", ":Files<CR>", { noremap = true })
+-- a comment
+local ok, err = pcall(function() return trim("  x  ") end)
+if not ok then print(err) elseif ok == nil then print("nil") end
+while false do end
+repeat local x = 1 until true
+
+return M
@@ -0,0 +1,51 @@
+#!/usr/bin/perl
+use strict;
+use warnings;
+use Getopt::Long;
+use File::Basename qw(basename);
+
+my $verbose = 0;
+my %counts;
+my @files;
+
+GetOptions('verbose!' => \$verbose) or die "usage: $0 [--verbose] files\n";
+
+sub log_msg {
+    my ($msg) = @_;
+    print STDERR "$msg\n" if $verbose;
+}
+
+foreach my $file (@ARGV) {
+    open(my $fh, '<', $file) or die "Cannot open $file: $!";
+    while (my $line = <$fh>) {
+        chomp $line;
+        next if $line =~ /^\s*#/;
+        for my $word (split /\s+/, $line) {
+            $counts{lc $word}++;
+        }
+    }
+    close($fh);
+    push @files, basename($file);
+    log_msg("read $file");
+}
+
+for my $word (sort { $counts{$b} <=> $counts{$a} } keys %counts) {
+    printf "%-20s %d\n", $word, $counts{$word};
+}
+
+my $ref = { name => 'Jane', tags => [qw(a b c)] };
+print $ref->{name}, "\n";
+print scalar(@{ $ref->{tags} }), "\n";
+
+unless (@files) {
+    warn "no files\n";
+}
+
+my $text = "hello world";
+$text =~ s/world/there/g;
+print "$text\n" if defined $text;
+local $_ = 'x';
+package Counter;
+sub new { my $class = shift; return bless {}, $class; }
+1;
+__END__
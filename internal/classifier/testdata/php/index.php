@@ -0,0 +1,57 @@
+<?php
+
+declare(strict_types=1);
+
+namespace App\Controller;
+
+use App\Model\User;
+use PDO;
+
+class UserController
+{
+    private PDO $db;
+
+    public function __construct(PDO $db)
+    {
+        $this->db = $db;
+    }
+
+    public function show(int $id): ?array
+    {
+        $stmt = $this->db->prepare('SELECT * FROM users WHERE id = :id');
+        $stmt->execute(['id' => $id]);
+        $user = $stmt->fetch(PDO::FETCH_ASSOC);
+        return $user ?: null;
+    }
+
+    public static function render(array $users): string
+    {
+        $html = '';
+        foreach ($users as $key => $user) {
+            $name = htmlspecialchars($user['name'], ENT_QUOTES);
+            $html .= "<li>{$name}</li>";
+        }
+        return $html;
+    }
+}
+
+function get_param(string $name, $default = null)
+{
+    return isset($_GET[$name]) ? $_GET[$name] : $default;
+}
+
+$controller = new UserController(new PDO('sqlite::memory:'));
+$id = (int) get_param('id', 1);
+$user = $controller->show($id);
+
+if ($user === null) {
+    http_response_code(404);
+    echo "Not found";
+    exit;
+}
+
+$items = array_map(fn($x) => $x * 2, [1, 2, 3]);
+echo json_encode(['user' => $user, 'items' => $items]);
+echo UserController::render([$user]);
+?>
+<p><?= $user['name'] ?></p>
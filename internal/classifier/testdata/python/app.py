@@ -0,0 +1,63 @@
+import os
+import sys
+from collections import defaultdict
+from typing import List, Optional
+
+
+class Inventory:
+    """Keep track of the items in a store."""
+
+    def __init__(self, name: str, items: Optional[List[str]] = None) -> None:
+        self.name = name
+        self.items = items or []
+        self.counts = defaultdict(int)
+
+    def add(self, item: str, count: int = 1) -> None:
+        if count <= 0:
+            raise ValueError(f"invalid count: {count}")
+        self.counts[item] += count
+
+    def __repr__(self):
+        return f"Inventory({self.name!r})"
+
+    @property
+    def total(self):
+        return sum(self.counts.values())
+
+
+def read_lines(path):
+    with open(path, encoding="utf-8") as f:
+        for line in f:
+            line = line.strip()
+            if not line or line.startswith("#"):
+                continue
+            yield line
+
+
+def main(argv):
+    inventory = Inventory("main")
+    for path in argv[1:]:
+        try:
+            for line in read_lines(path):
+                inventory.add(line)
+        except FileNotFoundError as e:
+            print(e, file=sys.stderr)
+            return 1
+    squares = [x * x for x in range(10) if x % 2 == 0]
+    print(squares, inventory.total)
+    data = {"name": None, "ok": True, "value": False}
+    for key, value in data.items():
+        print(key, value)
+    lambda_fn = lambda x: x + 1
+    elif_test = 3
+    if elif_test is None:
+        pass
+    elif elif_test in (1, 2):
+        pass
+    else:
+        print("done")
+    return 0
+
+
+if __name__ == "__main__":
+    sys.exit(main(sys.argv))
@@ -0,0 +1,62 @@
+import json
+import math
+from dataclasses import dataclass, field
+
+
+@dataclass
+class Sample:
+    name: str
+    values: list = field(default_factory=list)
+
+    def mean(self):
+        if not self.values:
+            return 0.0
+        return sum(self.values) / len(self.values)
+
+    def stddev(self):
+        m = self.mean()
+        return math.sqrt(sum((v - m) ** 2 for v in self.values) / len(self.values))
+
+
+def greet(name):
+    print(f"hello {name}")
+    return None
+
+
+def load(path):
+    with open(path) as f:
+        data = json.load(f)
+    return [Sample(**item) for item in data]
+
+
+async def fetch(session, url):
+    async with session.get(url) as response:
+        return await response.text()
+
+
+class Base(object):
+    def __init__(self, *args, **kwargs):
+        super().__init__()
+        self._args = args
+
+    def __str__(self):
+        return "Base(%s)" % ", ".join(map(str, self._args))
+
+
+def fib(n):
+    a, b = 0, 1
+    while a < n:
+        yield a
+        a, b = b, a + b
+
+
+if __name__ == "__main__":
+    import argparse
+    parser = argparse.ArgumentParser(description="Statistics")
+    parser.add_argument("path")
+    args = parser.parse_args()
+    for sample in load(args.path):
+        print(sample.name, round(sample.mean(), 2))
+    global counter
+    assert len(list(fib(10))) > 0, "empty"
+    del parser
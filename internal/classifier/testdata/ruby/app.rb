@@ -0,0 +1,73 @@
+require 'json'
+require_relative 'helpers'
+
+module Shop
+  class Product
+    attr_reader :name, :price
+    attr_accessor :quantity
+
+    def initialize(name, price, quantity = 0)
+      @name = name
+      @price = price
+      @quantity = quantity
+    end
+
+    def total
+      @price * @quantity
+    end
+
+    def to_s
+      "#{@name}: #{total}"
+    end
+
+    def self.from_hash(hash)
+      new(hash['name'], hash['price'], hash.fetch('quantity', 0))
+    end
+  end
+
+  class Cart
+    include Enumerable
+
+    def initialize
+      @products = []
+    end
+
+    def <<(product)
+      @products << product
+      self
+    end
+
+    def each(&block)
+      @products.each(&block)
+    end
+
+    def total
+      @products.sum(&:total)
+    end
+  end
+end
+
+cart = Shop::Cart.new
+products = JSON.parse(File.read('products.json'))
+products.each do |hash|
+  cart << Shop::Product.from_hash(hash)
+end
+
+cart.select { |p| p.quantity > 0 }.each do |product|
+  puts product
+end
+
+unless cart.total.zero?
+  puts "Total: #{cart.total}"
+end
+
+begin
+  raise ArgumentError, 'oops' if cart.nil?
+rescue ArgumentError => e
+  puts e.message
+ensure
+  puts 'done'
+end
+
+names = %w[apple banana].map(&:upcase)
+puts names.inspect if defined?(names)
@@ -0,0 +1,61 @@
+use std::collections::HashMap;
+use std::fmt;
+use std::fs::File;
+use std::io::{self, BufRead, BufReader};
+
+#[derive(Debug, Clone, PartialEq)]
+pub struct Point {
+    pub x: f64,
+    pub y: f64,
+}
+
+impl fmt::Display for Point {
+    fn fmt(&self, f: &mut fmt::Formatter<'_>) -> fmt::Result {
+        write!(f, "({}, {})", self.x, self.y)
+    }
+}
+
+pub enum Shape {
+    Circle { center: Point, radius: f64 },
+    Square(Point, f64),
+}
+
+impl Shape {
+    pub fn area(&self) -> f64 {
+        match self {
+            Shape::Circle { radius, .. } => std::f64::consts::PI * radius * radius,
+            Shape::Square(_, side) => side * side,
+        }
+    }
+}
+
+trait Named {
+    fn name(&self) -> String;
+}
+
+fn count_words(path: &str) -> io::Result<HashMap<String, usize>> {
+    let file = File::open(path)?;
+    let mut counts: HashMap<String, usize> = HashMap::new();
+    for line in BufReader::new(file).lines() {
+        let line = line?;
+        for word in line.split_whitespace() {
+            *counts.entry(word.to_lowercase()).or_insert(0) += 1;
+        }
+    }
+    Ok(counts)
+}
+
+fn main() {
+    let shapes: Vec<Shape> = vec![Shape::Square(Point { x: 0.0, y: 0.0 }, 2.0)];
+    let total: f64 = shapes.iter().map(|s| s.area()).sum();
+    println!("total area: {}", total);
+    match count_words("input.txt") {
+        Ok(counts) => println!("{:?}", counts),
+        Err(e) => eprintln!("error: {}", e),
+    }
+    let mut v = Vec::new();
+    v.push(1u32);
+    if let Some(first) = v.first() {
+        println!("{}", first);
+    }
+}
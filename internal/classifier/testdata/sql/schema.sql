@@ -0,0 +1,37 @@
+CREATE TABLE users (
+    id SERIAL PRIMARY KEY,
+    email VARCHAR(255) NOT NULL UNIQUE,
+    name TEXT,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE orders (
+    id INTEGER PRIMARY KEY,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    total DECIMAL(10, 2) NOT NULL DEFAULT 0
+);
+
+CREATE INDEX idx_orders_user ON orders (user_id);
+
+INSERT INTO users (email, name) VALUES ('jane@example.com', 'Jane');
+
+SELECT u.name, COUNT(o.id) AS order_count, SUM(o.total) AS total
+FROM users u
+LEFT JOIN orders o ON o.user_id = u.id
+WHERE u.created_at > '2024-01-01'
+GROUP BY u.name
+HAVING COUNT(o.id) > 1
+ORDER BY total DESC
+LIMIT 10;
+
+UPDATE orders SET total = total * 1.1 WHERE user_id IN (SELECT id FROM users WHERE name IS NULL);
+
+DELETE FROM users WHERE id NOT IN (SELECT DISTINCT user_id FROM orders);
+
+ALTER TABLE users ADD COLUMN active BOOLEAN DEFAULT TRUE;
+
+select * from users where email like '%@example.com' and active = true;
+
+BEGIN;
+DROP TABLE IF EXISTS sessions;
+COMMIT;
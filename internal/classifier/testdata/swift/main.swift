@@ -0,0 +1,68 @@
+import Foundation
+import UIKit
+
+protocol Shape {
+    var area: Double { get }
+    func describe() -> String
+}
+
+struct Circle: Shape {
+    let radius: Double
+
+    var area: Double {
+        return Double.pi * radius * radius
+    }
+
+    func describe() -> String {
+        return "Circle with radius \(radius)"
+    }
+}
+
+enum NetworkError: Error {
+    case badURL
+    case timeout(seconds: Int)
+}
+
+final class ViewController: UIViewController {
+    @IBOutlet weak var label: UILabel!
+    private var items: [String] = []
+
+    override func viewDidLoad() {
+        super.viewDidLoad()
+        label.text = "Hello"
+    }
+
+    func load(from urlString: String) async throws -> Data {
+        guard let url = URL(string: urlString) else {
+            throw NetworkError.badURL
+        }
+        let (data, _) = try await URLSession.shared.data(from: url)
+        return data
+    }
+}
+
+extension Array where Element == Int {
+    func sum() -> Int {
+        return reduce(0, +)
+    }
+}
+
+let shapes: [Shape] = [Circle(radius: 2.0)]
+for shape in shapes {
+    print(shape.describe())
+}
+
+var optionalName: String? = nil
+if let name = optionalName {
+    print("Hello, \(name)")
+} else {
+    print("No name")
+}
+
+let doubled = [1, 2, 3].map { $0 * 2 }
+switch doubled.count {
+case 0:
+    print("empty")
+default:
+    print("\(doubled.count) items")
+}
@@ -0,0 +1,58 @@
+import { Injectable } from '@angular/core';
+import type { Request, Response } from 'express';
+
+export interface User {
+  id: number;
+  name: string;
+  email?: string;
+  roles: readonly string[];
+}
+
+export type Result<T> = { ok: true; value: T } | { ok: false; error: string };
+
+enum Status {
+  Active = 'active',
+  Disabled = 'disabled',
+}
+
+@Injectable({ providedIn: 'root' })
+export class UserService {
+  private readonly users: Map<number, User> = new Map();
+
+  constructor(private readonly http: HttpClient) {}
+
+  public async find(id: number): Promise<Result<User>> {
+    const user: User | undefined = this.users.get(id);
+    if (!user) {
+      return { ok: false, error: `no user ${id}` };
+    }
+    return { ok: true, value: user };
+  }
+
+  add(user: User): void {
+    this.users.set(user.id, user);
+  }
+}
+
+export function handler(req: Request, res: Response): void {
+  const id: number = Number(req.params.id);
+  const names: Array<string> = [];
+  let count: number = 0;
+  const status: Status = Status.Active;
+  res.json({ id, names, count, status });
+}
+
+export abstract class Shape {
+  abstract area(): number;
+}
+
+function identity<T>(value: T): T {
+  return value;
+}
+
+declare module 'config' {
+  export const port: number;
+}
+
+const keys = Object.keys({} as Record<string, unknown>) as (keyof User)[];
+export default UserService;
@@ -0,0 +1,249 @@
+package splash
+
+import (
+	"fmt"
+	"path"
+	"regexp"
+	"strings"
+
+	"github.com/alecthomas/chroma/v2"
+	"github.com/alecthomas/chroma/v2/lexers"
+)
+
+// LanguageMethod is the kind of hint that decided the language of a block
+type LanguageMethod string
+
+const (
+	// LanguageFromClass is a class like "language-go", "lang-go" or "highlight-go"
+	LanguageFromClass LanguageMethod = "class"
+	// LanguageFromAttribute is a data-lang or data-language attribute
+	LanguageFromAttribute LanguageMethod = "attribute"
+	// LanguageFromSuffix is a suffix like {:go} at the end of inline code
+	LanguageFromSuffix LanguageMethod = "suffix"
+	// LanguageFromFilename is a filename in a title or data-filename attribute
+	LanguageFromFilename LanguageMethod = "filename"
+	// LanguageFromShebang is a #! line at the start of the code
+	LanguageFromShebang LanguageMethod = "shebang"
+	// LanguageFromModeline is an Emacs or Vim modeline in the code
+	LanguageFromModeline LanguageMethod = "modeline"
+	// LanguageFromClassifier is when the classifier of WithLanguageClassifier recognized the code
+	LanguageFromClassifier LanguageMethod = "classifier"
+	// LanguageFromAnalysis is when chroma recognized the code
+	LanguageFromAnalysis LanguageMethod = "analysis"
+	// LanguageFromDefault is the default language of the Highlighter
+	LanguageFromDefault LanguageMethod = "default"
+	// LanguageFromFallback is when no other language could be used
+	LanguageFromFallback LanguageMethod = "fallback"
+)
+
+// LanguageChoice describes how the language of a block was chosen
+type LanguageChoice struct {
+	Lexer  string         // the name of the chosen chroma lexer, like "Go"
+	Method LanguageMethod // the kind of hint that decided the language
+	Hint   string         // the hint, like "language-go" or "main.go"
+	Reason string         // a description of why the hint was picked
+}
+
+// WithLanguageHandler sets a function that is called with the language
+// choice for every block, which can be useful for finding blocks where the
+// language had to be guessed.
+func WithLanguageHandler(handler func(LanguageChoice)) Option {
+	return func(h *Highlighter) {
+		h.languageHandler = handler
+	}
+}
+
+var (
+	// emacsModelineRegexp matches an Emacs modeline like "-*- mode: python -*-" or "-*- python -*-"
+	emacsModelineRegexp = regexp.MustCompile(`-\*-\s*(.*?)\s*-\*-`)
+	// vimModelineRegexp matches a Vim modeline like "vim: set ft=python :" or "vi: syntax=python"
+	vimModelineRegexp = regexp.MustCompile(`(?:^|\s)(?:vi|vim|ex):.*?\b(?:ft|filetype|syntax)=([\w+#.-]+)`)
+)
+
+// modelineLines is how many lines at the start and at the end of the code
+// are searched for modelines, like Vim does by default
+const modelineLines = 5
+
+// classLanguagePrefixes are the class prefixes that declare a language, in order of precedence
+var classLanguagePrefixes = []string{"language-", "lang-", "highlight-source-", "highlight-"}
+
+// declaredLanguages returns the languages that the block declares with its
+// classes and data attributes, in order of precedence
+func (b *codeBlock) declaredLanguages() []LanguageChoice {
+	var (
+		choices []LanguageChoice
+		seen    = make(map[string]bool) // classes that match more than one prefix, like "highlight-source-go"
+	)
+	tags := []rawToken{b.outer}
+	if b.inner != nil {
+		tags = []rawToken{*b.inner, b.outer}
+	}
+	for _, prefix := range classLanguagePrefixes {
+		for _, t := range tags {
+			for _, c := range classes(t.token) {
+				if language := strings.TrimPrefix(c, prefix); language != c && language != "" && !seen[c] {
+					seen[c] = true
+					choices = append(choices, LanguageChoice{Method: LanguageFromClass, Hint: c, Reason: fmt.Sprintf("the class %q", c)})
+				}
+			}
+		}
+	}
+	for _, key := range []string{"data-lang", "data-language"} {
+		if language, ok := b.attr(key); ok && strings.TrimSpace(language) != "" {
+			choices = append(choices, LanguageChoice{Method: LanguageFromAttribute, Hint: strings.TrimSpace(language), Reason: fmt.Sprintf("the %s attribute", key)})
+		}
+	}
+	return choices
+}
+
+// languageOf returns the language name in a declared language hint
+func (choice LanguageChoice) languageOf() string {
+	if choice.Method != LanguageFromClass {
+		return choice.Hint
+	}
+	for _, prefix := range classLanguagePrefixes {
+		if language, found := strings.CutPrefix(choice.Hint, prefix); found {
+			return language
+		}
+	}
+	return choice.Hint
+}
+
+// lexerForFilename returns the lexer for the filename in a title or
+// data-filename attribute, like "main.go" or "Example: cmd/main.go"
+func (b *codeBlock) lexerForFilename() (chroma.Lexer, LanguageChoice) {
+	for _, key := range []string{"data-filename", "title"} {
+		value, ok := b.attr(key)
+		if !ok {
+			continue
+		}
+		fields := strings.Fields(value)
+		if len(fields) == 0 {
+			continue
+		}
+		// The filename is usually the last word of a title
+		filename := path.Base(fields[len(fields)-1])
+		if lexer := lexers.Match(filename); lexer != nil {
+			return lexer, LanguageChoice{Method: LanguageFromFilename, Hint: filename, Reason: fmt.Sprintf("the filename %q in the %s attribute", filename, key)}
+		}
+	}
+	return nil, LanguageChoice{}
+}
+
+// lexerForShebang returns the lexer for the interpreter in a #! line at the
+// start of the source, like "#!/usr/bin/env python3"
+func (h *Highlighter) lexerForShebang(source string) (chroma.Lexer, LanguageChoice) {
+	firstLine, _, _ := strings.Cut(source, "\n")
+	if !strings.HasPrefix(firstLine, "#!") {
+		return nil, LanguageChoice{}
+	}
+	fields := strings.Fields(strings.TrimPrefix(firstLine, "#!"))
+	if len(fields) == 0 {
+		return nil, LanguageChoice{}
+	}
+	interpreter := path.Base(fields[0])
+	if interpreter == "env" {
+		// Skip options like -S for env
+		interpreter = ""
+		for _, field := range fields[1:] {
+			if !strings.HasPrefix(field, "-") {
+				interpreter = path.Base(field)
+				break
+			}
+		}
+	}
+	// Try both "python3" and "python", or "lua5.1" and "lua"
+	for _, name := range []string{interpreter, strings.TrimRight(interpreter, "0123456789.")} {
+		if name == "" {
+			continue
+		}
+		if lexer := h.lexerByName(name); lexer != nil {
+			return lexer, LanguageChoice{Method: LanguageFromShebang, Hint: firstLine, Reason: fmt.Sprintf("the interpreter %q in the #! line", interpreter)}
+		}
+	}
+	return nil, LanguageChoice{}
+}
+
+// lexerForModeline returns the lexer for an Emacs or Vim modeline in the
+// first or last lines of the source
+func (h *Highlighter) lexerForModeline(source string) (chroma.Lexer, LanguageChoice) {
+	lines := strings.Split(source, "\n")
+	candidates := lines
+	if len(lines) > 2*modelineLines {
+		candidates = append(lines[:modelineLines:modelineLines], lines[len(lines)-modelineLines:]...)
+	}
+	for _, line := range candidates {
+		var language string
+		if match := emacsModelineRegexp.FindStringSubmatch(line); match != nil {
+			language = emacsMode(match[1])
+		} else if match := vimModelineRegexp.FindStringSubmatch(line); match != nil {
+			language = match[1]
+		}
+		if language == "" {
+			continue
+		}
+		if lexer := h.lexerByName(language); lexer != nil {
+			return lexer, LanguageChoice{Method: LanguageFromModeline, Hint: strings.TrimSpace(line), Reason: fmt.Sprintf("the mode %q in a modeline", language)}
+		}
+	}
+	return nil, LanguageChoice{}
+}
+
+// emacsMode returns the mode from the variables of an Emacs modeline, like
+// "python" from "mode: python; coding: utf-8" or from "python"
+func emacsMode(variables string) string {
+	if !strings.Contains(variables, ":") {
+		return strings.TrimSpace(variables)
+	}
+	for _, variable := range strings.Split(variables, ";") {
+		if key, value, found := strings.Cut(variable, ":"); found && strings.EqualFold(strings.TrimSpace(key), "mode") {
+			return strings.TrimSpace(value)
+		}
+	}
+	return ""
+}
+
+// chooseLexer returns the lexer for the given block and its source code,
+// together with the hint that decided it. Declared languages are used
+// first, then filenames, shebang lines and modelines, and only then is the
+// language guessed.
+func (h *Highlighter) chooseLexer(block *codeBlock, source string) (chroma.Lexer, LanguageChoice) {
+	lexer, choice := h.findLexer(block, source)
+	choice.Lexer = lexer.Config().Name
+	if h.languageHandler != nil {
+		h.languageHandler(choice)
+	}
+	return lexer, choice
+}
+
+// findLexer tries the language hints in order of precedence
+func (h *Highlighter) findLexer(block *codeBlock, source string) (chroma.Lexer, LanguageChoice) {
+	for _, choice := range block.declaredLanguages() {
+		// Try to use the specified language
+		if lexer := h.lexerByName(choice.languageOf()); lexer != nil {
+			return lexer, choice
+		}
+	}
+	if lexer, choice := block.lexerForFilename(); lexer != nil {
+		return lexer, choice
+	}
+	if lexer, choice := h.lexerForShebang(source); lexer != nil {
+		return lexer, choice
+	}
+	if lexer, choice := h.lexerForModeline(source); lexer != nil {
+		return lexer, choice
+	}
+	if h.classify {
+		return h.lexerFromClassifier(source)
+	}
+	// Try to identify the language based on the source code that is to be highlighted
+	if lexer := lexers.Analyse(source); lexer != nil {
+		return lexer, LanguageChoice{Method: LanguageFromAnalysis, Reason: "chroma recognized the code"}
+	}
+	// Could not identify the language, use the default language
+	if lexer := h.lexerByName(h.defaultLanguage); lexer != nil {
+		return lexer, LanguageChoice{Method: LanguageFromDefault, Hint: h.defaultLanguage, Reason: "no hint was found and the code was not recognized"}
+	}
+	// Could not use the default language, use the fallback
+	return lexers.Fallback, LanguageChoice{Method: LanguageFromFallback, Reason: fmt.Sprintf("the default language %q is unknown", h.defaultLanguage)}
+}
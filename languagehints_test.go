@@ -0,0 +1,58 @@
+package splash
+
+import (
+	"testing"
+)
+
+func TestLanguageHints(t *testing.T) {
+	tests := []struct {
+		input  string
+		lexer  string
+		method LanguageMethod
+	}{
+		{"<pre><code class=\"language-go\">x := 1</code></pre>", "Go", LanguageFromClass},
+		{"<pre><code class=\"lang-python\">x = 1</code></pre>", "Python", LanguageFromClass},
+		{"<div class=\"highlight highlight-source-ruby\"><pre>x = 1</pre></div>", "", ""}, // the class is not on <pre> or <code>
+		{"<pre class=\"highlight-source-ruby\">x = 1</pre>", "Ruby", LanguageFromClass},
+		{"<pre data-lang=\"rust\">let x = 1;</pre>", "Rust", LanguageFromAttribute},
+		{"<pre><code data-language=\"lua\">x = 1</code></pre>", "Lua", LanguageFromAttribute},
+		{"<pre class=\"language-nonsense\" data-lang=\"go\">x := 1</pre>", "Go", LanguageFromAttribute},
+		{"<pre title=\"Example: cmd/main.go\">x := 1</pre>", "Go", LanguageFromFilename},
+		{"<pre data-filename=\"build.rs\">let x = 1;</pre>", "Rust", LanguageFromFilename},
+		{"<pre>#!/usr/bin/env python3\nx = 1</pre>", "Python", LanguageFromShebang},
+		{"<pre>#!/bin/bash\nx=1</pre>", "Bash", LanguageFromShebang},
+		{"<pre># -*- mode: ruby; coding: utf-8 -*-\nx = 1</pre>", "Ruby", LanguageFromModeline},
+		{"<pre>x = 1\n-- vim: set ft=lua :</pre>", "Lua", LanguageFromModeline},
+		{"<pre>x = 1</pre>", "Bash", LanguageFromDefault},
+	}
+	for _, test := range tests {
+		var choices []LanguageChoice
+		h := New(WithLanguageHandler(func(choice LanguageChoice) {
+			choices = append(choices, choice)
+		}))
+		if _, _, err := h.Highlight([]byte(test.input)); err != nil {
+			t.Fatal(err)
+		}
+		if test.lexer == "" {
+			if len(choices) > 0 && choices[0].Method == LanguageFromClass {
+				t.Errorf("%s: expected the class on <div> to be ignored, got %+v", test.input, choices[0])
+			}
+			continue
+		}
+		if len(choices) != 1 {
+			t.Fatalf("%s: expected one language choice, got %d", test.input, len(choices))
+		}
+		if choices[0].Lexer != test.lexer || choices[0].Method != test.method {
+			t.Errorf("%s: expected %s from %s, got %+v", test.input, test.lexer, test.method, choices[0])
+		}
+		if choices[0].Reason == "" {
+			t.Errorf("%s: expected a reason for the choice", test.input)
+		}
+	}
+}
+
+func TestEmacsMode(t *testing.T) {
+	assertEqual(t, "python", emacsMode("python"), "")
+	assertEqual(t, "python", emacsMode("mode: python; coding: utf-8"), "")
+	assertEqual(t, "", emacsMode("coding: utf-8"), "")
+}
@@ -0,0 +1,81 @@
+package splash
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/alecthomas/chroma/v2"
+	"github.com/alecthomas/chroma/v2/lexers"
+)
+
+// LanguageInfo describes a language that can be highlighted
+type LanguageInfo struct {
+	Name      string   // the name of the chroma lexer, like "Go"
+	Aliases   []string // other names, like "go" and "golang", including the ones added with AddLanguageAlias
+	Filenames []string // filename globs, like "*.go"
+	MimeTypes []string // MIME types, like "text/x-gosrc"
+}
+
+// languageAliases holds the aliases added with AddLanguageAlias
+var languageAliases = struct {
+	sync.RWMutex
+	byAlias map[string]string // lowercase alias -> lexer name
+}{byAlias: make(map[string]string)}
+
+// AddLanguageAlias makes a language name, like "golang" in class="language-golang",
+// refer to a language that chroma knows, like "go". The alias takes precedence
+// over the names that chroma knows. An error is returned if the language is unknown.
+func AddLanguageAlias(alias, language string) error {
+	lexer := lexers.Get(language)
+	if lexer == nil {
+		return fmt.Errorf("can not add the alias %q for the unknown language %q", alias, language)
+	}
+	languageAliases.Lock()
+	defer languageAliases.Unlock()
+	languageAliases.byAlias[strings.ToLower(strings.TrimSpace(alias))] = lexer.Config().Name
+	return nil
+}
+
+// lexerByName returns the lexer for the given language name, or nil.
+// Aliases added with AddLanguageAlias are looked up first.
+func (h *Highlighter) lexerByName(language string) chroma.Lexer {
+	languageAliases.RLock()
+	name, ok := languageAliases.byAlias[strings.ToLower(strings.TrimSpace(language))]
+	languageAliases.RUnlock()
+	if ok {
+		language = name
+	}
+	return lexers.Get(language)
+}
+
+// Languages returns all the languages that can be highlighted, sorted by name
+func Languages() []LanguageInfo {
+	extra := make(map[string][]string) // lexer name -> aliases added with AddLanguageAlias
+	languageAliases.RLock()
+	for alias, name := range languageAliases.byAlias {
+		extra[name] = append(extra[name], alias)
+	}
+	languageAliases.RUnlock()
+
+	infos := make([]LanguageInfo, 0, len(lexers.GlobalLexerRegistry.Lexers))
+	for _, lexer := range lexers.GlobalLexerRegistry.Lexers {
+		config := lexer.Config()
+		info := LanguageInfo{
+			Name:      config.Name,
+			Aliases:   append([]string(nil), config.Aliases...),
+			Filenames: append(append([]string(nil), config.Filenames...), config.AliasFilenames...),
+			MimeTypes: append([]string(nil), config.MimeTypes...),
+		}
+		sort.Strings(extra[config.Name])
+		for _, alias := range extra[config.Name] {
+			if !containsFold(info.Aliases, alias) {
+				info.Aliases = append(info.Aliases, alias)
+			}
+		}
+		infos = append(infos, info)
+	}
+	sort.Slice(infos, func(i, j int) bool { return strings.ToLower(infos[i].Name) < strings.ToLower(infos[j].Name) })
+	return infos
+}
@@ -0,0 +1,48 @@
+package splash
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestLanguages(t *testing.T) {
+	languages := Languages()
+	if len(languages) < 100 {
+		t.Fatalf("expected all the chroma lexers, got %d", len(languages))
+	}
+	var found bool
+	for _, language := range languages {
+		if language.Name == "Go" {
+			found = true
+			if !containsFold(language.Aliases, "golang") || !containsFold(language.Filenames, "*.go") || len(language.MimeTypes) == 0 {
+				t.Errorf("expected aliases, filenames and MIME types for Go: %+v", language)
+			}
+		}
+	}
+	if !found {
+		t.Error("expected Go in the language catalog")
+	}
+}
+
+func TestAddLanguageAlias(t *testing.T) {
+	if err := AddLanguageAlias("test-gopher", "go"); err != nil {
+		t.Fatal(err)
+	}
+	if err := AddLanguageAlias("test-nothing", "no-such-language"); err == nil {
+		t.Error("expected an error for an unknown language")
+	}
+
+	htmlBytes, _, err := New().Highlight([]byte("<pre><code class=\"language-test-gopher\">package main\n\nfunc main() {}</code></pre>"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(htmlBytes), "class=\"kd\"") {
+		t.Errorf("expected the code to be highlighted as Go: %s", htmlBytes)
+	}
+
+	for _, language := range Languages() {
+		if language.Name == "Go" && !containsFold(language.Aliases, "test-gopher") {
+			t.Errorf("expected the added alias in the language catalog: %v", language.Aliases)
+		}
+	}
+}
@@ -0,0 +1,166 @@
+package splash
+
+import (
+	"errors"
+	"fmt"
+	"html"
+	"strings"
+	"time"
+
+	"github.com/alecthomas/chroma/v2"
+)
+
+// ErrLimit is wrapped by LimitError, for use with errors.Is
+var ErrLimit = errors.New("limit exceeded")
+
+// DefaultMaxBlockSize is the size of a block, in bytes, that is read into
+// memory at most when Limits.MaxBlockSize is not set, so that a <pre> that
+// is never closed does not make the rest of the HTML be read into memory
+const DefaultMaxBlockSize = 1 << 20
+
+// Limits protects against untrusted input, like pathological code that makes
+// the lexers backtrack for a long time. A block of code that exceeds a limit
+// is left as plain text, and a *LimitError is reported as a warning. Blocks
+// that are too big are not read into memory, but passed through as they are.
+// Zero means no limit, except for MaxBlockSize, where it means DefaultMaxBlockSize.
+type Limits struct {
+	MaxBlockSize    int           // the maximum size of a block in the HTML, in bytes, including the tags
+	MaxLineLength   int           // the maximum length of a line of code, in bytes, for ie. minified JavaScript
+	MaxBlocks       int           // the maximum number of blocks that are highlighted in a document
+	MaxTokeniseTime time.Duration // the maximum time for tokenising the code in a block
+}
+
+// WithLimits sets limits for the size of the blocks of code, the length of
+// the lines, the number of blocks and the time spent tokenising each block.
+// Blocks that exceed a limit are left as escaped plain text, or as they were
+// for MaxBlockSize, and reported as a *LimitError to the warning handler and
+// in the Err field of the report.
+func WithLimits(limits Limits) Option {
+	return func(h *Highlighter) {
+		h.limits = limits
+	}
+}
+
+// LimitError is reported when a block of code is left as plain text, since
+// it exceeds one of the Limits
+type LimitError struct {
+	Index  int    // the position of the block in the document, from 0
+	Offset int    // the byte offset of the block in the input
+	Limit  string // the name of the limit, like "MaxBlockSize"
+	Value  int64  // the size, length, number of blocks or nanoseconds that exceeded the limit
+	Max    int64  // the limit
+}
+
+func (e *LimitError) Error() string {
+	value, maxValue := fmt.Sprint(e.Value), fmt.Sprint(e.Max)
+	if e.Limit == "MaxTokeniseTime" {
+		value, maxValue = time.Duration(e.Value).String(), time.Duration(e.Max).String()
+	}
+	return fmt.Sprintf("block %d at offset %d is left as plain text: %s is more than %s (%s)", e.Index, e.Offset, value, e.Limit, maxValue)
+}
+
+func (e *LimitError) Unwrap() error {
+	return ErrLimit
+}
+
+// checkLimits returns a *LimitError if the block with the given index
+// exceeds the limits for the number of blocks, the size or the line length
+func (h *Highlighter) checkLimits(block *codeBlock, index int) *LimitError {
+	limitErr := &LimitError{Index: index, Offset: block.offset}
+	switch {
+	case block.tooBig > 0:
+		// The scanner stopped reading the block at MaxBlockSize
+		limitErr.Limit, limitErr.Value, limitErr.Max = "MaxBlockSize", int64(block.tooBig), int64(h.maxBlockSize())
+	case h.limits.MaxBlocks > 0 && index >= h.limits.MaxBlocks:
+		limitErr.Limit, limitErr.Value, limitErr.Max = "MaxBlocks", int64(index+1), int64(h.limits.MaxBlocks)
+	case h.limits.MaxLineLength > 0:
+		longest := 0
+		for line := range strings.SplitSeq(block.source, "\n") {
+			longest = max(longest, len(line))
+		}
+		if longest <= h.limits.MaxLineLength {
+			return nil
+		}
+		limitErr.Limit, limitErr.Value, limitErr.Max = "MaxLineLength", int64(longest), int64(h.limits.MaxLineLength)
+	default:
+		return nil
+	}
+	return limitErr
+}
+
+// maxBlockSize returns the size of a block, in bytes, that is read into memory at most
+func (h *Highlighter) maxBlockSize() int {
+	if h.limits.MaxBlockSize > 0 {
+		return h.limits.MaxBlockSize
+	}
+	return DefaultMaxBlockSize
+}
+
+// tokeniseLimiter is a lexer interceptor that stops returning tokens when
+// MaxTokeniseTime has passed. It must be applied before chroma.Coalesce,
+// since that reads ahead for as long as the tokens have the same type.
+type tokeniseLimiter struct {
+	chroma.Lexer
+	maxTime time.Duration
+	elapsed time.Duration // the time it took before the tokens were stopped, or 0
+}
+
+func (l *tokeniseLimiter) Tokenise(options *chroma.TokeniseOptions, text string) (chroma.Iterator, error) {
+	it, err := l.Lexer.Tokenise(options, text)
+	if err != nil {
+		return nil, err
+	}
+	start := time.Now()
+	deadline := start.Add(l.maxTime)
+	return func() chroma.Token {
+		if l.elapsed > 0 {
+			return chroma.EOF
+		}
+		if now := time.Now(); now.After(deadline) {
+			l.elapsed = now.Sub(start)
+			return chroma.EOF
+		}
+		return it()
+	}, nil
+}
+
+// timeLimited returns a lexer that stops when MaxTokeniseTime has passed,
+// together with a function that returns a *LimitError if it did
+func (h *Highlighter) timeLimited(lexer chroma.Lexer) (chroma.Lexer, func() *LimitError) {
+	if h.limits.MaxTokeniseTime <= 0 {
+		return lexer, func() *LimitError { return nil }
+	}
+	limiter := &tokeniseLimiter{Lexer: lexer, maxTime: h.limits.MaxTokeniseTime}
+	return limiter, func() *LimitError {
+		if limiter.elapsed == 0 {
+			return nil
+		}
+		return &LimitError{Limit: "MaxTokeniseTime", Value: int64(limiter.elapsed), Max: int64(h.limits.MaxTokeniseTime)}
+	}
+}
+
+// plainBlock returns the block with its code as escaped plain text, and
+// reports why as a warning
+func (h *Highlighter) plainBlock(style *chroma.Style, block *codeBlock, limitErr *LimitError) *renderedBlock {
+	h.warn(limitErr)
+	language := LanguageChoice{Lexer: plainText.Config().Name, Method: LanguageFromFallback, Reason: limitErr.Error()}
+	if block.tooBig > 0 {
+		// Only the start tag has been read, the rest of the block is passed through as it is
+		return &renderedBlock{html: block.raw, style: style, language: language, limitErr: limitErr}
+	}
+	source := h.blockSource(block)
+	if block.wrapper == wrapInline {
+		// Keep any whitespace at the end of inline code
+		source = block.source
+		if h.unescape {
+			source = html.UnescapeString(source)
+		}
+	}
+	return &renderedBlock{
+		html:     block.wrap([]byte(html.EscapeString(source)), h.blockClass(style, style), ""),
+		style:    style,
+		language: language,
+		lines:    strings.Count(source, "\n") + 1,
+		limitErr: limitErr,
+	}
+}
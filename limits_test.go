@@ -0,0 +1,130 @@
+package splash
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/alecthomas/chroma/v2"
+	"github.com/alecthomas/chroma/v2/lexers"
+)
+
+// slowLexer is a lexer that never stops returning tokens
+type slowLexer struct{}
+
+func (slowLexer) Config() *chroma.Config { return &chroma.Config{Name: "splash-slow"} }
+
+func (slowLexer) Tokenise(*chroma.TokeniseOptions, string) (chroma.Iterator, error) {
+	return func() chroma.Token {
+		time.Sleep(time.Millisecond)
+		return chroma.Token{Type: chroma.Text, Value: "x"}
+	}, nil
+}
+
+func (l slowLexer) SetRegistry(*chroma.LexerRegistry) chroma.Lexer     { return l }
+func (l slowLexer) SetAnalyser(func(text string) float32) chroma.Lexer { return l }
+func (slowLexer) AnalyseText(string) float32                           { return 0 }
+
+func init() {
+	lexers.Register(slowLexer{})
+}
+
+func TestLimits(t *testing.T) {
+	tests := []struct {
+		limits Limits
+		input  string
+		limit  string
+	}{
+		{Limits{MaxBlockSize: 10}, "<pre class=\"language-go\">x := \"a very long string\"</pre>", "MaxBlockSize"},
+		{Limits{MaxLineLength: 10}, "<pre class=\"language-go\">x := 1\ny := \"a very long line\"\n</pre>", "MaxLineLength"},
+		{Limits{MaxBlocks: 1}, "<pre class=\"language-go\">x := 1</pre><pre class=\"language-go\">y &lt; 2</pre>", "MaxBlocks"},
+		{Limits{MaxTokeniseTime: 20 * time.Millisecond}, "<pre class=\"language-splash-slow\">a &lt; b</pre>", "MaxTokeniseTime"},
+	}
+	for _, test := range tests {
+		var warnings []error
+		h := New(WithLimits(test.limits), WithWarningHandler(func(err error) {
+			warnings = append(warnings, err)
+		}))
+		start := time.Now()
+		htmlData, _, report, err := h.HighlightReport([]byte(test.input))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if elapsed := time.Since(start); elapsed > time.Second {
+			t.Errorf("%s: expected highlighting to stop, it took %v", test.limit, elapsed)
+		}
+		var limitErr *LimitError
+		if len(warnings) != 1 || !errors.As(warnings[0], &limitErr) || limitErr.Limit != test.limit || !errors.Is(limitErr, ErrLimit) {
+			t.Errorf("%s: expected one *LimitError, got %v", test.limit, warnings)
+			continue
+		}
+		last := report.Blocks[len(report.Blocks)-1]
+		if last.Err != limitErr || last.Language.Method != LanguageFromFallback || limitErr.Index != last.Index || limitErr.Offset != last.Start {
+			t.Errorf("%s: expected the last block to be reported as plain text, got %+v", test.limit, last)
+		}
+		if bytes.Contains(htmlData[bytes.LastIndex(htmlData, []byte("<pre")):], []byte("<span")) {
+			t.Errorf("%s: expected plain text, got %s", test.limit, htmlData)
+		}
+	}
+}
+
+func TestLimitsEscapePlainText(t *testing.T) {
+	htmlData, _, err := New(WithLimits(Limits{MaxBlockSize: 1}), WithUnescape(true)).Highlight([]byte("<pre><code class=\"language-html\">&lt;script&gt;alert(1)&lt;/script&gt;</code></pre>"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(htmlData), "&lt;script&gt;alert(1)&lt;/script&gt;</code></pre>") {
+		t.Errorf("expected the code to stay escaped, got %s", htmlData)
+	}
+}
+
+func TestWithinLimits(t *testing.T) {
+	var warnings []error
+	h := New(WithLimits(Limits{MaxBlockSize: 100, MaxLineLength: 20, MaxBlocks: 2, MaxTokeniseTime: time.Second}), WithWarningHandler(func(err error) {
+		warnings = append(warnings, err)
+	}))
+	htmlData, _, err := h.Highlight([]byte("<pre class=\"language-go\">x := 1</pre><pre class=\"language-go\">y := 2</pre>"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(warnings) > 0 || bytes.Count(htmlData, []byte("<span class=\"mi\">")) != 2 {
+		t.Errorf("expected both blocks to be highlighted, got %v and %s", warnings, htmlData)
+	}
+}
+
+func TestMaxBlockSizeWhileScanning(t *testing.T) {
+	// A block that is too big, or never closed, is passed through as it is
+	// after MaxBlockSize bytes, instead of being read to the end of the HTML
+	big := "<pre>" + strings.Repeat("x := 1\n", 1000) + "</pre>"
+	unclosed := "<pre>" + strings.Repeat("<code>x ", 8000)
+	for _, input := range []string{big + "<pre>y := 2</pre>", unclosed + "<pre>y := 2</pre>"} {
+		var warnings []error
+		h := New(WithLimits(Limits{MaxBlockSize: 1024}), WithWarningHandler(func(err error) {
+			warnings = append(warnings, err)
+		}))
+		var buf bytes.Buffer
+		start := time.Now()
+		if err := h.SplashStream(&buf, strings.NewReader(input)); err != nil {
+			t.Fatal(err)
+		}
+		if elapsed := time.Since(start); elapsed > time.Second {
+			t.Errorf("expected the scanning to be fast, it took %v", elapsed)
+		}
+		var limitErr *LimitError
+		if len(warnings) != 1 || !errors.As(warnings[0], &limitErr) || limitErr.Limit != "MaxBlockSize" || limitErr.Offset != 0 || limitErr.Value <= 1024 {
+			t.Errorf("expected one *LimitError for MaxBlockSize at offset 0, got %v", warnings)
+		}
+		output := buf.String()
+		prefix := input[:strings.LastIndex(input, "<pre>")]
+		i := strings.Index(output, prefix)
+		if i == -1 {
+			t.Errorf("expected the block that is too big to be passed through as it is: %.300s", output)
+			continue
+		}
+		if after := output[i+len(prefix):]; !strings.Contains(after, `<span class="line">`) {
+			t.Errorf("expected the block after it to be highlighted: %s", after)
+		}
+	}
+}
@@ -0,0 +1,168 @@
+package splash
+
+import (
+	"bytes"
+	"strconv"
+	"strings"
+
+	chromaHTML "github.com/alecthomas/chroma/v2/formatters/html"
+	"golang.org/x/net/html"
+)
+
+// LineNumbers is a mode for showing line numbers next to the code
+type LineNumbers int
+
+const (
+	// NoLineNumbers does not show any line numbers
+	NoLineNumbers LineNumbers = iota
+	// InlineLineNumbers shows line numbers at the start of each line
+	InlineLineNumbers
+	// TableLineNumbers shows line numbers in a separate table column,
+	// so that the code can be copied without them. The table is placed in
+	// a <div> with the CSS classes, around the <pre> of the block.
+	TableLineNumbers
+)
+
+// WithLineNumbers sets the line number mode for all blocks. Blocks can
+// override this with a data-line-numbers attribute on <pre> or <code>, which
+// can be "true", "inline", "table" or "false".
+func WithLineNumbers(mode LineNumbers) Option {
+	return func(h *Highlighter) {
+		h.lineNumbers = mode
+	}
+}
+
+// WithLineNumbersStart sets the first line number, which is 1 by default.
+// Blocks can override this with a data-start attribute on <pre> or <code>.
+func WithLineNumbersStart(n int) Option {
+	return func(h *Highlighter) {
+		h.lineStart = n
+	}
+}
+
+// lineNumbersFor returns the line number mode and first line number for the
+// given block, taking the data-line-numbers and data-start attributes into account.
+func (h *Highlighter) lineNumbersFor(block *codeBlock) (LineNumbers, int) {
+	mode, start := h.lineNumbers, h.lineStart
+	if val, ok := block.attr("data-line-numbers"); ok {
+		switch strings.ToLower(strings.TrimSpace(val)) {
+		case "", "true", "yes", "1":
+			if mode == NoLineNumbers {
+				mode = InlineLineNumbers
+			}
+		case "inline":
+			mode = InlineLineNumbers
+		case "table":
+			mode = TableLineNumbers
+		case "false", "no", "0", "none":
+			mode = NoLineNumbers
+		}
+	}
+	if val, ok := block.attr("data-start"); ok {
+		if n, err := strconv.Atoi(strings.TrimSpace(val)); err == nil {
+			start = n
+		}
+	}
+	return mode, start
+}
+
+// blockFormatter returns a formatter for a block with the given line number
+// mode, first line number and highlighted line ranges. The formatter of the
+// Highlighter is used if possible.
+func (h *Highlighter) blockFormatter(mode LineNumbers, start int, highlighted [][2]int) *chromaHTML.Formatter {
+	config := h.formatterConfig()
+	config.lineNumbers = mode
+	if config == h.formatterConfig() && start == 1 && len(highlighted) == 0 {
+		return h.formatter
+	}
+	options := h.formatterOptions[:len(h.formatterOptions):len(h.formatterOptions)]
+	if start != 1 {
+		options = append(options, chromaHTML.BaseLineNumber(start))
+	}
+	if len(highlighted) > 0 {
+		// chroma compares the ranges with the line numbers, which may not start at 1
+		shifted := make([][2]int, len(highlighted))
+		for i, r := range highlighted {
+			shifted[i] = [2]int{r[0] + start - 1, r[1] + start - 1}
+		}
+		options = append(options, chromaHTML.HighlightLines(shifted))
+	}
+	if len(options) == 0 {
+		return sharedFormatter(config)
+	}
+	return newFormatter(config, options...)
+}
+
+// cssFormatter returns a formatter that can write the CSS for blocks that use
+// line number modes up to and including the given mode.
+func (h *Highlighter) cssFormatter(mode LineNumbers) *chromaHTML.Formatter {
+	return h.cssFormatters[mode]
+}
+
+// newCSSFormatters creates the formatters that are returned by cssFormatter.
+// They are created once, so that the stylesheets that are generated with
+// them can be cached, also when there are formatter options.
+func (h *Highlighter) newCSSFormatters() [TableLineNumbers + 1]*chromaHTML.Formatter {
+	var formatters [TableLineNumbers + 1]*chromaHTML.Formatter
+	for mode := range formatters {
+		config := h.formatterConfig()
+		config.lineNumbers = LineNumbers(mode)
+		switch {
+		case config.lineNumbers <= h.lineNumbers:
+			formatters[mode] = h.formatter
+		case len(h.formatterOptions) == 0:
+			formatters[mode] = sharedFormatter(config)
+		default:
+			formatters[mode] = newFormatter(config, h.formatterOptions...)
+		}
+	}
+	return formatters
+}
+
+// The tags that tablePreWrapper writes around the code. Chroma escapes any
+// "<" in the code, so they can not be confused with the code.
+const (
+	tableCodeStart = "<splash-code>"
+	tableCodeEnd   = "</splash-code>"
+)
+
+// tablePreWrapper makes chroma write the line numbers in a <pre> of their
+// own, and mark where the code is, so that the code can be placed within the
+// <pre> and <code> tags of the original HTML, in the second column of the table.
+type tablePreWrapper struct{}
+
+func (tablePreWrapper) Start(code bool, styleAttr string) string {
+	if code {
+		return tableCodeStart
+	}
+	return "<pre><code>"
+}
+
+func (tablePreWrapper) End(code bool) string {
+	if code {
+		return tableCodeEnd
+	}
+	return "</code></pre>"
+}
+
+// wrapTable returns the table of line numbers and code that chroma wrote
+// with tablePreWrapper, with the code within the tags of the block. The
+// <div> around the table gets the given CSS classes, since a <pre> can not
+// contain a table.
+func wrapTable(block *codeBlock, hiBytes []byte, class string) []byte {
+	start := bytes.Index(hiBytes, []byte(tableCodeStart))
+	end := bytes.LastIndex(hiBytes, []byte(tableCodeEnd))
+	divEnd := bytes.IndexByte(hiBytes, '>') + 1
+	if start < divEnd || end < start {
+		return block.wrap(hiBytes, class, "")
+	}
+	z := html.NewTokenizer(bytes.NewReader(hiBytes[:divEnd]))
+	z.Next()
+
+	var buf bytes.Buffer
+	buf.WriteString(withAttrs(z.Token(), class, ""))
+	buf.Write(hiBytes[divEnd:start])
+	buf.Write(block.wrap(hiBytes[start+len(tableCodeStart):end], "", ""))
+	buf.Write(bytes.TrimRight(hiBytes[end+len(tableCodeEnd):], "\n"))
+	return buf.Bytes()
+}
@@ -0,0 +1,122 @@
+package splash
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"golang.org/x/net/html"
+)
+
+const threeLines = "<pre><code class=\"language-go\">a := 1\nb := 2\nc := 3</code></pre>"
+
+func TestLineNumbers(t *testing.T) {
+	htmlBytes, _, err := New(WithLineNumbers(InlineLineNumbers), WithLineNumbersStart(10)).Highlight([]byte(threeLines))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, n := range []string{"10", "11", "12"} {
+		if !strings.Contains(string(htmlBytes), `<span class="ln">`+n+`</span>`) {
+			t.Errorf("expected line number %s: %s", n, htmlBytes)
+		}
+	}
+
+	htmlBytes, cssBytes, err := New(WithLineNumbers(TableLineNumbers)).Highlight([]byte(threeLines))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(htmlBytes), `<table class="lntable">`) {
+		t.Errorf("expected line numbers in a table: %s", htmlBytes)
+	}
+	if !bytes.Contains(cssBytes, []byte(".chroma .lntd:last-child")) {
+		t.Errorf("expected CSS for line numbers in a table: %s", cssBytes)
+	}
+}
+
+func TestTableLineNumbersStructure(t *testing.T) {
+	for _, inlineStyles := range []bool{false, true} {
+		input := `<body><pre class="x"><code class="language-go">a := 1` + "\n" + `b := 2</code></pre></body>`
+		htmlBytes, _, err := New(WithLineNumbers(TableLineNumbers), WithInlineStyles(inlineStyles)).Highlight([]byte(input))
+		if err != nil {
+			t.Fatal(err)
+		}
+		doc, err := html.Parse(bytes.NewReader(htmlBytes))
+		if err != nil {
+			t.Fatal(err)
+		}
+		var pres []*html.Node
+		var tables int
+		for n := range doc.Descendants() {
+			switch {
+			case n.Type == html.ElementNode && n.Data == "pre":
+				pres = append(pres, n)
+			case n.Type == html.ElementNode && n.Data == "table":
+				tables++
+				if n.Parent.Data != "div" || !strings.Contains(attrValue(n.Parent, "class"), "chroma") {
+					t.Errorf("expected the table to be within <div class=\"chroma\">: %s", htmlBytes)
+				}
+			}
+		}
+		assertEqual(t, 1, tables, "expected one table: "+string(htmlBytes))
+		// One <pre> for the line numbers and one for the code, each in a column of its own
+		assertEqual(t, 2, len(pres), "expected two <pre> tags: "+string(htmlBytes))
+		for _, pre := range pres {
+			if pre.Parent.Data != "td" {
+				t.Errorf("expected <pre> to be within <td>: %s", htmlBytes)
+			}
+		}
+		if len(pres) == 2 {
+			assertEqual(t, "x", attrValue(pres[1], "class"), "expected the <pre> of the code to keep its attributes")
+			assertEqual(t, "language-go", attrValue(pres[1].FirstChild, "class"), "expected the <code> of the code to keep its attributes")
+		}
+		if strings.Contains(string(htmlBytes), "splash-code") || strings.Contains(string(htmlBytes), "</div>\n") {
+			t.Errorf("unexpected markers or newlines in the output: %s", htmlBytes)
+		}
+	}
+}
+
+// attrValue returns the value of the given attribute of the node, or ""
+func attrValue(n *html.Node, key string) string {
+	if n == nil {
+		return ""
+	}
+	for _, a := range n.Attr {
+		if a.Key == key {
+			return a.Val
+		}
+	}
+	return ""
+}
+
+func TestLineNumbersPerBlock(t *testing.T) {
+	input := strings.Replace(threeLines, "<pre>", `<pre data-line-numbers="true" data-start="42">`, 1) + threeLines
+	htmlBytes, _, err := Highlight([]byte(input), "monokai", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	output := string(htmlBytes)
+	assertEqual(t, 3, strings.Count(output, `<span class="ln">`), "expected line numbers for only the first block: "+output)
+	if !strings.Contains(output, `<span class="ln">42</span>`) {
+		t.Errorf("expected the line numbers to start at 42: %s", output)
+	}
+
+	// A block can turn off line numbers
+	input = strings.Replace(threeLines, "<code ", `<code data-line-numbers="false" `, 1)
+	htmlBytes, _, err = New(WithLineNumbers(InlineLineNumbers)).Highlight([]byte(input))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(htmlBytes), `class="ln"`) {
+		t.Errorf("expected no line numbers: %s", htmlBytes)
+	}
+
+	// A block can use a table, and then the CSS for tables is included
+	input = strings.Replace(threeLines, "<pre>", `<pre data-line-numbers="table">`, 1)
+	htmlBytes, cssBytes, err := Highlight([]byte(input), "monokai", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(htmlBytes), `<table class="lntable">`) || !bytes.Contains(cssBytes, []byte(".chroma .lntd:last-child")) {
+		t.Errorf("expected line numbers in a table: %s\n%s", htmlBytes, cssBytes)
+	}
+}
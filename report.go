@@ -0,0 +1,110 @@
+package splash
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"github.com/alecthomas/chroma/v2"
+)
+
+// BlockReport describes how one block of code was highlighted
+type BlockReport struct {
+	Index       int            // the position of the block in the document, from 0
+	Start       int            // the byte offset of the block in the input, including the tags
+	End         int            // the byte offset right after the block in the input, or after the part that was read if it exceeds MaxBlockSize
+	Inline      bool           // true for inline <code> elements
+	Declared    string         // the language declared by a class, attribute or suffix, like "go", or ""
+	Language    LanguageChoice // the chosen lexer, and how it was chosen
+	Lines       int            // the number of lines of code
+	Style       string         // the name of the style that was used for the block
+	ErrorTokens []string       // the text of the tokens that the lexer could not handle
+	Elapsed     time.Duration  // the time it took to highlight the block
+	Err         error          // the *BlockError if the block was left as it was in isolation mode, or the *LimitError if it was left as plain text
+}
+
+// Report describes how each block of code in a document was highlighted
+type Report struct {
+	Blocks []BlockReport
+}
+
+// ByMethod returns the blocks whose language was chosen by one of the given
+// methods, like LanguageFromDefault and LanguageFromFallback for the blocks
+// where no language could be found.
+func (r *Report) ByMethod(methods ...LanguageMethod) []BlockReport {
+	var blocks []BlockReport
+	for _, block := range r.Blocks {
+		if slices.Contains(methods, block.Language.Method) {
+			blocks = append(blocks, block)
+		}
+	}
+	return blocks
+}
+
+// WithErrorTokens returns the blocks where the lexer found code it could not handle
+func (r *Report) WithErrorTokens() []BlockReport {
+	var blocks []BlockReport
+	for _, block := range r.Blocks {
+		if len(block.ErrorTokens) > 0 {
+			blocks = append(blocks, block)
+		}
+	}
+	return blocks
+}
+
+// HighlightReport does the same as Highlight, but also returns a report with
+// one entry per block of code. The report covers the blocks up to the first
+// error, if there is one, or all the blocks in isolation mode.
+func (h *Highlighter) HighlightReport(htmlData []byte) ([]byte, []byte, *Report, error) {
+	report := &Report{}
+	htmlOut, cssData, err := h.highlight(context.Background(), htmlData, report)
+	return htmlOut, cssData, report, err
+}
+
+// add adds an entry for the given block to the report
+func (r *Report) add(block *codeBlock, rendered *renderedBlock, elapsed time.Duration, err error) {
+	entry := BlockReport{
+		Index:       len(r.Blocks),
+		Start:       block.offset,
+		End:         block.offset + max(len(block.raw), block.tooBig),
+		Inline:      block.wrapper == wrapInline,
+		Declared:    block.declaredLanguage(),
+		Language:    rendered.language,
+		Lines:       rendered.lines,
+		ErrorTokens: rendered.errorTokens,
+		Elapsed:     elapsed,
+		Err:         err,
+	}
+	if rendered.limitErr != nil {
+		entry.Err = rendered.limitErr
+	}
+	if rendered.style != nil {
+		entry.Style = rendered.style.Name
+	}
+	r.Blocks = append(r.Blocks, entry)
+}
+
+// declaredLanguage returns the first language that the block declares, even
+// if it is unknown, or an empty string
+func (b *codeBlock) declaredLanguage() string {
+	if b.wrapper == wrapInline {
+		language, _ := inlineLanguage(b, b.source)
+		return language
+	}
+	if choices := b.declaredLanguages(); len(choices) > 0 {
+		return choices[0].languageOf()
+	}
+	return ""
+}
+
+// collectErrorTokens returns an iterator that passes on the tokens from the
+// given iterator, while appending the text of any error tokens to found
+func collectErrorTokens(iterator chroma.Iterator, found *[]string) chroma.Iterator {
+	return func() chroma.Token {
+		t := iterator()
+		if t.Type == chroma.Error {
+			*found = append(*found, t.Value)
+		}
+		return t
+	}
+}
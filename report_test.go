@@ -0,0 +1,71 @@
+package splash
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestHighlightReport(t *testing.T) {
+	input := "<p>Intro</p>\n" +
+		"<pre><code class=\"language-go\">package main\n\nfunc main() {}\n</code></pre>\n" +
+		"<pre class=\"language-nonsense\">x = 1</pre>\n" +
+		"<pre>#!/usr/bin/env python3\nprint(1)</pre>"
+	h := New(WithStyle("monokai"))
+	htmlData, cssData, report, err := h.HighlightReport([]byte(input))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(htmlData) == 0 || len(cssData) == 0 {
+		t.Fatal("expected HTML and CSS")
+	}
+	if len(report.Blocks) != 3 {
+		t.Fatalf("expected 3 blocks, got %d", len(report.Blocks))
+	}
+
+	first := report.Blocks[0]
+	if first.Index != 0 || first.Declared != "go" || first.Language.Lexer != "Go" || first.Language.Method != LanguageFromClass {
+		t.Errorf("unexpected report for the first block: %+v", first)
+	}
+	if !strings.HasPrefix(input[first.Start:first.End], "<pre><code") || !strings.HasSuffix(input[first.Start:first.End], "</code></pre>") {
+		t.Errorf("expected the offsets to cover the first block, got %q", input[first.Start:first.End])
+	}
+	if first.Lines != 3 || first.Style != "monokai" {
+		t.Errorf("expected 3 lines with monokai, got %d lines with %q", first.Lines, first.Style)
+	}
+
+	second := report.Blocks[1]
+	if second.Declared != "nonsense" || second.Language.Method != LanguageFromDefault {
+		t.Errorf("expected the unknown language to fall back to the default, got %+v", second)
+	}
+	if defaults := report.ByMethod(LanguageFromDefault, LanguageFromFallback); len(defaults) != 1 || defaults[0].Index != 1 {
+		t.Errorf("expected only the second block to use the default language, got %+v", defaults)
+	}
+
+	third := report.Blocks[2]
+	if third.Declared != "" || third.Language.Method != LanguageFromShebang || !strings.HasSuffix(input, input[third.Start:third.End]) {
+		t.Errorf("unexpected report for the third block: %+v", third)
+	}
+}
+
+func TestReportErrorTokens(t *testing.T) {
+	_, _, report, err := New().HighlightReport([]byte("<pre class=\"language-json\">{\"a\": 1} ` ^</pre>"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if blocks := report.WithErrorTokens(); len(blocks) != 1 || len(blocks[0].ErrorTokens) == 0 {
+		t.Errorf("expected error tokens, got %+v", report.Blocks)
+	}
+}
+
+func TestReportInlineCode(t *testing.T) {
+	_, _, report, err := New(WithInlineCode(true)).HighlightReport([]byte("<p>Use <code>fmt.Println(x){:go}</code>.</p>"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(report.Blocks) != 1 {
+		t.Fatalf("expected one block, got %+v", report.Blocks)
+	}
+	if block := report.Blocks[0]; !block.Inline || block.Declared != "go" || block.Language.Method != LanguageFromSuffix || block.Language.Lexer != "Go" {
+		t.Errorf("unexpected report for inline code: %+v", block)
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"os"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/russross/blackfriday"
 )
@@ -212,8 +213,71 @@ func main() {
 
 	//fmt.Println("TEST OUTPUT\n", output)
 
-	if strings.Contains(output, `pre class="chroma"`) {
-		t.Fatal("pre code with existing class should not be formatted with chroma!")
+	if !strings.Contains(output, `pre class="existing chroma"`) {
+		t.Fatal("pre code with an existing class should keep the class and be formatted with chroma!")
+	}
+}
+
+func TestBlockVariants(t *testing.T) {
+	variants := []string{
+		`<pre>x := 1</pre>`,
+		`<PRE>x := 1</PRE>`,
+		`<pre class="x">x := 1</pre>`,
+		`<pre id='a'   class="x">x := 1</pre>`,
+		`<pre><code>x := 1</code></pre>`,
+		"<pre>\n  <code class=\"language-go\" id=\"b\">x := 1</code>\n</pre>",
+		`<code><pre>x := 1</pre></code>`,
+		`<code class="language-go"><pre class="y">x := 1</pre></code>`,
+	}
+	for _, variant := range variants {
+		htmlBytes, _, err := Highlight([]byte("<body>"+variant+"</body>"), "monokai", false)
+		if err != nil {
+			t.Fatal(err)
+		}
+		output := string(htmlBytes)
+		if !strings.Contains(output, `<span class="line">`) {
+			t.Errorf("%s was not highlighted: %s", variant, output)
+		}
+		assertEqual(t, strings.Count(strings.ToLower(variant), "<pre"), strings.Count(output, "<pre"), "<pre count differs for "+variant)
+		assertEqual(t, strings.Count(variant, "<code"), strings.Count(output, "<code"), "<code count differs for "+variant)
+	}
+
+	// Escaped tags in the source code should not end the block
+	htmlBytes, _, err := Highlight([]byte(`<pre>a &lt;/pre&gt; b</pre><p>after</p>`), "monokai", true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasSuffix(string(htmlBytes), "</pre><p>after</p>") {
+		t.Errorf("unexpected output: %s", htmlBytes)
+	}
+}
+
+func TestUnclosedTags(t *testing.T) {
+	// Each unclosed tag should not make the scanner read to the end of the HTML again
+	for _, tag := range []string{"<code>x ", "<pre>x "} {
+		input := "<body>" + strings.Repeat(tag, 8000) + "<pre>x := 1</pre></body>"
+		done := make(chan struct{})
+		var htmlBytes []byte
+		var err error
+		go func() {
+			htmlBytes, _, err = New(WithInlineCode(true)).Highlight([]byte(input))
+			close(done)
+		}()
+		select {
+		case <-done:
+		case <-time.After(5 * time.Second):
+			t.Fatalf("scanning %d unclosed %q tags took too long", 8000, tag)
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		output := string(htmlBytes)
+		if !strings.HasPrefix(output, "<body>"+strings.Repeat(tag, 8000)) {
+			t.Errorf("the unclosed %q tags were not passed through as they were", tag)
+		}
+		if !strings.Contains(output, `<span class="line">`) {
+			t.Errorf("the closed block after the unclosed %q tags was not highlighted", tag)
+		}
 	}
 }
 
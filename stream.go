@@ -0,0 +1,214 @@
+package splash
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"io"
+
+	"github.com/alecthomas/chroma/v2"
+	"golang.org/x/net/html"
+)
+
+// SplashStream reads HTML from r and writes it to w, while syntax highlighting
+// code between <pre> and </pre> tags. Only one block of code is kept in memory
+// at the time, the rest of the HTML is copied straight through. Blocks that
+// are larger than DefaultMaxBlockSize, or Limits.MaxBlockSize if it is set,
+// are copied straight through too, like a <pre> that is never closed.
+//
+// "style" is a syntax highlight style, like "monokai".
+//
+// The CSS is added in a <style> tag right after <head>. If there is no <head>,
+// it is added in a new <head> right after <html>. If neither <head> nor <html>
+// appear before the first block of code, the <style> tag is written just before it.
+func SplashStream(w io.Writer, r io.Reader, styleName string) error {
+	return defaultHighlighter.Load().with(WithStyle(styleName)).SplashStream(w, r)
+}
+
+// UnescapeSplashStream does the same as SplashStream, but unescapes the HTML
+// in the source code before highlighting.
+func UnescapeSplashStream(w io.Writer, r io.Reader, styleName string) error {
+	return defaultHighlighter.Load().with(WithStyle(styleName), WithUnescape(true)).SplashStream(w, r)
+}
+
+// streamState keeps track of where the CSS should be written while streaming
+type streamState int
+
+const (
+	cssPending   streamState = iota // no place for the CSS has been found yet
+	cssAfterHTML                    // <html> has been seen, waiting to see if <head> comes next
+	cssWritten                      // the CSS has been written
+)
+
+// SplashStream reads HTML from r and writes it to w, while syntax highlighting
+// code between <pre> and </pre> tags. See the package level SplashStream for
+// where the CSS is placed. In isolation mode, BlockErrors may be returned
+// after all the HTML has been written. For other errors, including errors
+// from writing to w, the HTML written to w ends where the error happened.
+func (h *Highlighter) SplashStream(w io.Writer, r io.Reader) error {
+	return h.splashStream(context.Background(), w, r)
+}
+
+// splashStream does the work for SplashStream and SplashStreamContext
+func (h *Highlighter) splashStream(ctx context.Context, w io.Writer, r io.Reader) error {
+	style, err := h.style(h.styleName)
+	if err != nil {
+		return err
+	}
+
+	ew := &errWriter{w: w}
+	var (
+		bw          = bufio.NewWriter(ew)
+		scanner     = newBlockScanner(r, h.inlineBlocks(), h.maxBlockSize())
+		state       = cssPending
+		held        []rawToken // tokens after <html> that are held back until it is known where the CSS goes
+		cssData     []byte
+		documentCSS []byte                         // the document CSS as generated by chroma, used for scoping
+		scoped      = make(map[*chroma.Style]bool) // styles that override the document style and have CSS written
+		index       int                            // the position of the next block
+		blockErrs   BlockErrors                    // the blocks that failed, in isolation mode
+	)
+
+	if h.inlineStyles {
+		// No <style> tag is needed
+		state = cssWritten
+	} else {
+		// The CSS depends only on the style, so it can be generated before reading any HTML.
+		// Blocks may turn on line numbers, so include the CSS for those.
+		formatter := h.cssFormatter(TableLineNumbers)
+		sheet, err := stylesheets.get(formatter, style)
+		if err != nil {
+			return err
+		}
+		documentCSS = sheet.css
+		if cssData, err = h.sheetCSS(formatter, sheet, nil, nil); err != nil {
+			return err
+		}
+	}
+
+	writeStyle := func() {
+		bw.WriteString("<style>")
+		bw.Write(cssData)
+		bw.WriteString("</style>\n")
+		state = cssWritten
+	}
+
+	flushHeld := func() {
+		for _, t := range held {
+			bw.Write(t.raw)
+		}
+		held = nil
+	}
+
+	// Write the HTML that has been handled so far, also when returning early
+	defer bw.Flush()
+
+	for {
+		// Stop reading as soon as w fails
+		if ew.err != nil {
+			return ew.err
+		}
+		t, block, err := scanner.Scan()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+
+		if block != nil {
+			switch state {
+			case cssAfterHTML:
+				// There was no <head> after <html>
+				bw.WriteString("<head>")
+				writeStyle()
+				bw.WriteString("</head>")
+				flushHeld()
+			case cssPending:
+				writeStyle()
+			}
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+			rendered, blockErr := h.renderBlock(ctx, style, block, index)
+			index++
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+			if blockErr != nil {
+				if !h.isolateErrors {
+					return blockErr
+				}
+				// Leave the block as it was
+				blockErrs = append(blockErrs, blockErr)
+				bw.Write(rendered.html)
+				continue
+			}
+			if rendered.style != style && !h.inlineStyles && !scoped[rendered.style] {
+				// Write the CSS for a style that overrides the document style, right before the first block that uses it
+				overrideSheet, err := stylesheets.get(h.cssFormatter(TableLineNumbers), rendered.style)
+				if err != nil {
+					return err
+				}
+				bw.WriteString("<style>")
+				bw.Write(stripCSS(h.finishCSS(scopeCSS(overrideSheet.css, h.classPrefix+scopeClass(rendered.style), documentCSS, h.classPrefix), nil)))
+				bw.WriteString("</style>")
+				scoped[rendered.style] = true
+			}
+			bw.Write(rendered.html)
+			continue
+		}
+
+		switch state {
+		case cssPending:
+			bw.Write(t.raw)
+			if isStartTag(t, "head") {
+				writeStyle()
+			} else if isStartTag(t, "html") {
+				state = cssAfterHTML
+			}
+		case cssAfterHTML:
+			switch {
+			case isStartTag(t, "head"):
+				flushHeld()
+				bw.Write(t.raw)
+				writeStyle()
+			case t.token.Type == html.CommentToken || (t.token.Type == html.TextToken && len(bytes.TrimSpace(t.raw)) == 0):
+				held = append(held, t)
+			default:
+				// There was no <head> after <html>
+				bw.WriteString("<head>")
+				writeStyle()
+				bw.WriteString("</head>")
+				flushHeld()
+				bw.Write(t.raw)
+			}
+		default:
+			bw.Write(t.raw)
+		}
+	}
+
+	flushHeld()
+	if err := bw.Flush(); err != nil {
+		return err
+	}
+	return blockErrs.asError()
+}
+
+// errWriter remembers the first error from writing to w, so that the
+// streaming can stop as soon as w fails, even though writes are buffered
+type errWriter struct {
+	w   io.Writer
+	err error
+}
+
+func (e *errWriter) Write(p []byte) (int, error) {
+	if e.err != nil {
+		return 0, e.err
+	}
+	n, err := e.w.Write(p)
+	if err != nil {
+		e.err = err
+	}
+	return n, err
+}
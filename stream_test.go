@@ -0,0 +1,147 @@
+package splash
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestSplashStream(t *testing.T) {
+	input := "<!doctype html><html><head><title>Stream</title></head><body>" + languageBlock + "<p>between</p><pre>x := 1</pre></body></html>"
+
+	var output bytes.Buffer
+	if err := UnescapeSplashStream(&output, strings.NewReader(input), "monokai"); err != nil {
+		t.Fatal(err)
+	}
+
+	expected, err := UnescapeSplash([]byte(input), "monokai")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The HTML after </style> should be the same as for UnescapeSplash
+	streamed := output.String()
+	assertEqual(t, 1, strings.Count(streamed, "<style>"), "expected exactly one <style> tag")
+	assertEqual(t, true, strings.HasPrefix(streamed, "<!doctype html><html><head><style>"), "the CSS should be placed right after <head>: "+streamed[:60])
+	streamedBody := streamed[strings.Index(streamed, "</style>"):]
+	expectedBody := string(expected[bytes.Index(expected, []byte("</style>")):])
+	assertEqual(t, expectedBody, streamedBody, "streamed HTML differs from UnescapeSplash")
+}
+
+func TestSplashStreamWithoutHead(t *testing.T) {
+	var output bytes.Buffer
+	if err := SplashStream(&output, strings.NewReader("<html>\n<body><pre>x := 1</pre></body></html>"), "monokai"); err != nil {
+		t.Fatal(err)
+	}
+	assertEqual(t, true, strings.HasPrefix(output.String(), "<html><head><style>"), "expected a new <head> after <html>: "+output.String())
+
+	// Fragments get the <style> tag right before the first block
+	output.Reset()
+	if err := SplashStream(&output, strings.NewReader("<p>hi</p><pre>x := 1</pre><pre>y := 2</pre>"), "monokai"); err != nil {
+		t.Fatal(err)
+	}
+	assertEqual(t, true, strings.HasPrefix(output.String(), "<p>hi</p><style>"), "expected <style> before the first block: "+output.String())
+	assertEqual(t, 1, strings.Count(output.String(), "<style>"), "expected exactly one <style> tag")
+
+	// HTML without any code is copied as it is
+	output.Reset()
+	if err := SplashStream(&output, strings.NewReader("<p>only <b>text</b></p>"), "monokai"); err != nil {
+		t.Fatal(err)
+	}
+	assertEqual(t, "<p>only <b>text</b></p>", output.String(), "")
+}
+
+// failingWriter fails after the given number of bytes have been written
+type failingWriter struct {
+	left int
+}
+
+func (w *failingWriter) Write(p []byte) (int, error) {
+	if len(p) > w.left {
+		n := w.left
+		w.left = 0
+		return n, errors.New("disk full")
+	}
+	w.left -= len(p)
+	return len(p), nil
+}
+
+// countingReader counts the bytes that have been read
+type countingReader struct {
+	r    io.Reader
+	read int
+}
+
+func (r *countingReader) Read(p []byte) (int, error) {
+	n, err := r.r.Read(p)
+	r.read += n
+	return n, err
+}
+
+func TestSplashStreamWriteError(t *testing.T) {
+	input := strings.Repeat("<p>text</p><pre>x := 1</pre>", 20000)
+	r := &countingReader{r: strings.NewReader(input)}
+	err := SplashStream(&failingWriter{left: 1000}, r, "monokai")
+	if err == nil || err.Error() != "disk full" {
+		t.Fatalf("expected the write error, got %v", err)
+	}
+	if r.read == len(input) {
+		t.Errorf("expected the streaming to stop when writing failed, but all the input was read")
+	}
+
+	// The HTML that was handled before an error is flushed
+	var buf bytes.Buffer
+	err = New().SplashStreamContext(cancelledContext(), &buf, strings.NewReader("<p>before</p><pre>x := 1</pre>"))
+	if err == nil || !strings.HasPrefix(buf.String(), "<p>before</p>") {
+		t.Errorf("expected the HTML before the error to be written, got %q and %v", buf.String(), err)
+	}
+}
+
+// cancelledContext returns a context that is already cancelled
+func cancelledContext() context.Context {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	return ctx
+}
+
+// progressReader records how much had been written to w when r was read to the end
+type progressReader struct {
+	r         io.Reader
+	w         *bytes.Buffer
+	writtenAt int
+}
+
+func (r *progressReader) Read(p []byte) (int, error) {
+	n, err := r.r.Read(p)
+	if err == io.EOF {
+		r.writtenAt = r.w.Len()
+	}
+	return n, err
+}
+
+func TestSplashStreamUnclosedPre(t *testing.T) {
+	// The HTML after a <pre> that is never closed is streamed, not read into memory until the end
+	input := "<p>intro</p><pre>x := 1\n" + strings.Repeat("<p>a paragraph of text</p>\n", 4*DefaultMaxBlockSize/27)
+	var warnings []error
+	h := New(WithWarningHandler(func(err error) {
+		warnings = append(warnings, err)
+	}))
+	var output bytes.Buffer
+	r := &progressReader{r: strings.NewReader(input), w: &output}
+	if err := h.SplashStream(&output, r); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasSuffix(output.String(), input[len("<p>intro</p>"):]) {
+		t.Errorf("expected the unclosed <pre> and the HTML after it to be copied as it is")
+	}
+	if r.writtenAt < len(input)-2*DefaultMaxBlockSize {
+		t.Errorf("expected the HTML to be written while reading, only %d of %d bytes were written when the input ended", r.writtenAt, len(input))
+	}
+	var limitErr *LimitError
+	if len(warnings) != 1 || !errors.As(warnings[0], &limitErr) || limitErr.Max != DefaultMaxBlockSize {
+		t.Errorf("expected one *LimitError for DefaultMaxBlockSize, got %v", warnings)
+	}
+}
@@ -1,14 +1,14 @@
-package main
+package splash
 
 // This file is auto-generated by cmd/selfupdate
 // It maps XML filenames to the actual style names registered in chroma
 
-type styleMapping struct {
+type styleFile struct {
 	fileName  string // XML filename without .xml extension
 	styleName string // Style name as defined in the XML
 }
 
-var styles = []styleMapping{
+var styleFiles = []styleFile{
 	{fileName: "abap", styleName: "abap"},
 	{fileName: "algol", styleName: "algol"},
 	{fileName: "algol_nu", styleName: "algol_nu"},
@@ -0,0 +1,184 @@
+package splash
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/alecthomas/chroma/v2"
+	"github.com/alecthomas/chroma/v2/styles"
+)
+
+// ErrUnknownStyle is wrapped by UnknownStyleError, for use with errors.Is
+var ErrUnknownStyle = errors.New("unknown style")
+
+// UnknownStyleError is returned for a style name that can not be found,
+// when the style is looked up with LookupStyle or in strict mode.
+type UnknownStyleError struct {
+	Name        string   // the style name that was given
+	Suggestions []string // the closest style names, the best match first
+}
+
+func (e *UnknownStyleError) Error() string {
+	msg := fmt.Sprintf("unknown style %q", e.Name)
+	switch len(e.Suggestions) {
+	case 0:
+		return msg
+	case 1:
+		return fmt.Sprintf("%s, did you mean %q?", msg, e.Suggestions[0])
+	}
+	quoted := make([]string, len(e.Suggestions))
+	for i, suggestion := range e.Suggestions {
+		quoted[i] = fmt.Sprintf("%q", suggestion)
+	}
+	return fmt.Sprintf("%s, did you mean %s or %s?", msg, strings.Join(quoted[:len(quoted)-1], ", "), quoted[len(quoted)-1])
+}
+
+func (e *UnknownStyleError) Unwrap() error {
+	return ErrUnknownStyle
+}
+
+// maxSuggestions is the maximum number of suggestions for an unknown style
+const maxSuggestions = 3
+
+// WithStrictStyles can be set to true for returning an *UnknownStyleError
+// for style names that can not be found, instead of using the fallback style.
+func WithStrictStyles(strict bool) Option {
+	return func(h *Highlighter) {
+		h.strictStyles = strict
+	}
+}
+
+// LookupStyle returns the style with the given name. Registered styles,
+// chroma styles by name and by XML filename, and names like "aura-theme-dark"
+// for "Aura Theme Dark" are all found. If the style can not be found, an
+// *UnknownStyleError with the closest matches is returned.
+func LookupStyle(styleName string) (*chroma.Style, error) {
+	if style := lookupStyle(styleName); style != nil {
+		return style, nil
+	}
+	return nil, &UnknownStyleError{Name: styleName, Suggestions: suggestStyles(styleName)}
+}
+
+// lookupStyle returns the style with the given name, or nil
+func lookupStyle(styleName string) *chroma.Style {
+	for _, name := range []string{styleName, normalizeStyleName(styleName)} {
+		if style := lookupCustomStyle(name); style != nil {
+			return style
+		}
+		if style, ok := styles.Registry[strings.ToLower(name)]; ok {
+			return style
+		}
+	}
+	key := styleNameKey(styleName)
+	for _, file := range styleFiles {
+		if styleNameKey(file.fileName) == key {
+			if style, ok := styles.Registry[strings.ToLower(file.styleName)]; ok {
+				return style
+			}
+		}
+	}
+	// Compare the names without case and separators, so that "Aura Theme Dark" also finds "aura-theme-dark"
+	for _, style := range styles.Registry {
+		if styleNameKey(style.Name) == key {
+			return style
+		}
+	}
+	return nil
+}
+
+// styleNameKey returns the style name in lowercase and without separators,
+// so that "Aura Theme Dark", "aura-theme-dark" and "aura_theme_dark" are equal
+func styleNameKey(name string) string {
+	return strings.Map(func(r rune) rune {
+		if r == '-' || r == '_' || r == ' ' {
+			return -1
+		}
+		return r
+	}, strings.ToLower(name))
+}
+
+// suggestStyles returns the known style names that are closest to the given
+// name, by edit distance between the names without case and separators
+func suggestStyles(styleName string) []string {
+	var candidates []string
+	for _, file := range styleFiles {
+		candidates = append(candidates, file.fileName)
+	}
+	for _, style := range styles.Registry {
+		candidates = append(candidates, style.Name)
+	}
+	customStyles.RLock()
+	for name := range customStyles.byName {
+		candidates = append(candidates, name)
+	}
+	customStyles.RUnlock()
+
+	type suggestion struct {
+		name     string
+		style    *chroma.Style
+		distance int
+	}
+	key := styleNameKey(styleName)
+	maxDistance := max(2, len(key)/3)
+	best := make(map[*chroma.Style]suggestion)
+	for _, candidate := range candidates {
+		candidateKey := styleNameKey(candidate)
+		distance := editDistance(key, candidateKey)
+		if key != "" && strings.HasPrefix(candidateKey, key) {
+			// A name like "solarized" is close to "solarized-dark"
+			distance = min(distance, 1)
+		}
+		if distance > maxDistance {
+			continue
+		}
+		style := lookupStyle(candidate)
+		if style == nil {
+			// The style list may name styles that this version of chroma does not have
+			continue
+		}
+		if previous, seen := best[style]; seen && (previous.distance < distance || (previous.distance == distance && previous.name <= candidate)) {
+			continue
+		}
+		best[style] = suggestion{candidate, style, distance}
+	}
+
+	suggestions := make([]suggestion, 0, len(best))
+	for _, s := range best {
+		suggestions = append(suggestions, s)
+	}
+	sort.Slice(suggestions, func(i, j int) bool {
+		if suggestions[i].distance != suggestions[j].distance {
+			return suggestions[i].distance < suggestions[j].distance
+		}
+		return suggestions[i].name < suggestions[j].name
+	})
+	names := make([]string, 0, maxSuggestions)
+	for i := 0; i < len(suggestions) && i < maxSuggestions; i++ {
+		names = append(names, suggestions[i].name)
+	}
+	return names
+}
+
+// editDistance returns the Levenshtein distance between two strings
+func editDistance(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	previous := make([]int, len(rb)+1)
+	current := make([]int, len(rb)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		current[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous, current = current, previous
+	}
+	return previous[len(rb)]
+}
@@ -0,0 +1,61 @@
+package splash
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestLookupStyle(t *testing.T) {
+	for _, name := range []string{"monokai", "Monokai", "rpgle", "RPGLE", "Aura Theme Dark", "aura-theme-dark", "aura_theme_dark"} {
+		if _, err := LookupStyle(name); err != nil {
+			t.Errorf("expected %q to be found: %v", name, err)
+		}
+	}
+	assertEqual(t, getStyle("aura-theme-dark"), getStyle("Aura Theme Dark"), "")
+}
+
+func TestUnknownStyle(t *testing.T) {
+	_, err := LookupStyle("monokia")
+	if !errors.Is(err, ErrUnknownStyle) {
+		t.Fatalf("expected ErrUnknownStyle, got %v", err)
+	}
+	var unknown *UnknownStyleError
+	if !errors.As(err, &unknown) {
+		t.Fatalf("expected an UnknownStyleError, got %v", err)
+	}
+	if len(unknown.Suggestions) == 0 || unknown.Suggestions[0] != "monokai" {
+		t.Errorf("expected monokai to be the first suggestion, got %v", unknown.Suggestions)
+	}
+
+	_, err = LookupStyle("solarized")
+	if !errors.As(err, &unknown) || len(unknown.Suggestions) != maxSuggestions {
+		t.Errorf("expected %d suggestions for solarized, got %v", maxSuggestions, err)
+	}
+
+	_, err = LookupStyle("qqqqqqqqqqqq")
+	if !errors.As(err, &unknown) || len(unknown.Suggestions) != 0 {
+		t.Errorf("expected no suggestions, got %v", err)
+	}
+}
+
+func TestStrictStyles(t *testing.T) {
+	input := []byte("<pre><code class=\"language-go\">func main() {}</code></pre>")
+	if _, _, err := New(WithStyle("githbu"), WithStrictStyles(true)).Highlight(input); !errors.Is(err, ErrUnknownStyle) {
+		t.Errorf("expected ErrUnknownStyle for the document style, got %v", err)
+	}
+	blockInput := []byte("<pre data-style=\"draculla\"><code class=\"language-go\">func main() {}</code></pre>")
+	if _, _, err := New(WithStrictStyles(true)).Highlight(blockInput); !errors.Is(err, ErrUnknownStyle) {
+		t.Errorf("expected ErrUnknownStyle for a block style, got %v", err)
+	}
+	// Without strict mode, the fallback style is used
+	if _, _, err := New(WithStyle("githbu")).Highlight(input); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestEditDistance(t *testing.T) {
+	assertEqual(t, 0, editDistance("monokai", "monokai"), "")
+	assertEqual(t, 2, editDistance("monokia", "monokai"), "")
+	assertEqual(t, 3, editDistance("kitten", "sitting"), "")
+	assertEqual(t, 4, editDistance("", "abcd"), "")
+}
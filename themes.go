@@ -0,0 +1,173 @@
+package splash
+
+import (
+	"bytes"
+	"html"
+	"slices"
+	"strings"
+
+	chromaHTML "github.com/alecthomas/chroma/v2/formatters/html"
+)
+
+// themeAttribute is the attribute that selects a theme, like <html data-splash-theme="monokai">
+const themeAttribute = "data-splash-theme"
+
+// WithThemes makes it possible to switch between the given styles at runtime,
+// without highlighting again. The CSS rules then use CSS custom properties,
+// like ".chroma .k { color: var(--splash-k) }", and the values are given per
+// style, like [data-splash-theme="monokai"] { --splash-k: #66d9ef }.
+// Setting the data-splash-theme attribute on <html> or on an element that
+// contains the code selects a theme. The style from WithStyle is used when no
+// theme has been selected. WithDarkStyle has no effect together with WithThemes.
+func WithThemes(styleNames ...string) Option {
+	return func(h *Highlighter) {
+		h.themes = append(h.themes[:len(h.themes):len(h.themes)], styleNames...)
+	}
+}
+
+// themeNames returns the names of the styles that can be switched between,
+// starting with the document style
+func (h *Highlighter) themeNames() []string {
+	names := []string{h.styleName}
+	for _, name := range h.themes {
+		if !slices.Contains(names, name) {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// declaration is a CSS property together with its value
+type declaration struct {
+	property string
+	value    string
+}
+
+// parseDeclarations splits the body of a CSS rule into declarations
+func parseDeclarations(body string) []declaration {
+	var declarations []declaration
+	for _, part := range strings.Split(body, ";") {
+		property, value, found := strings.Cut(part, ":")
+		if !found {
+			continue
+		}
+		declarations = append(declarations, declaration{strings.TrimSpace(property), strings.TrimSpace(value)})
+	}
+	return declarations
+}
+
+// cssVariable returns the name of the CSS custom property for the given
+// selector and property, like "--splash-k" for the color of ".chroma .k", or
+// "--splash-k-font-weight" for the font weight.
+func cssVariable(selector, property string) string {
+	fields := strings.Fields(selector)
+	name := strings.ReplaceAll(strings.TrimPrefix(fields[len(fields)-1], "."), ":", "-")
+	name = strings.ReplaceAll(name, ".", "-")
+	if property == "color" {
+		return "--splash-" + name
+	}
+	return "--splash-" + name + "-" + property
+}
+
+// themedCSS returns CSS where the values that differ between the themes are
+// CSS custom properties, followed by one block of values per theme. If used
+// is not nil, only the rules and variables for the used classes are included.
+func (h *Highlighter) themedCSS(formatter *chromaHTML.Formatter, used map[string]bool) ([]byte, error) {
+	var (
+		names      = h.themeNames()
+		selectors  []string                                // all selectors, in order of appearance
+		comments   = make(map[string]string)               // selector -> comment
+		properties = make(map[string][]string)             // selector -> properties, in order of appearance
+		values     = make([]map[string]string, len(names)) // per theme: selector and property -> value
+	)
+	for i, name := range names {
+		style, err := h.style(name)
+		if err != nil {
+			return nil, err
+		}
+		sheet, err := stylesheets.get(formatter, style)
+		if err != nil {
+			return nil, err
+		}
+		values[i] = make(map[string]string)
+		for _, rule := range parseCSS(sheet.css) {
+			if _, seen := properties[rule.selector]; !seen {
+				selectors = append(selectors, rule.selector)
+				comments[rule.selector] = rule.comment
+				properties[rule.selector] = []string{}
+			}
+			for _, d := range parseDeclarations(rule.body) {
+				key := rule.selector + "\x00" + d.property
+				if _, seen := values[i][key]; !seen && !slices.Contains(properties[rule.selector], d.property) {
+					properties[rule.selector] = append(properties[rule.selector], d.property)
+				}
+				values[i][key] = d.value
+			}
+		}
+	}
+
+	var (
+		rules     []cssRule
+		variables = make([][]string, len(names)) // per theme: "--splash-k: #66d9ef"
+	)
+	for _, selector := range selectors {
+		if class := (cssRule{selector: selector}).class(); used != nil && class != "" && !used[class] {
+			// The rule is pruned, so no variables are needed for it
+			continue
+		}
+		var body []string
+		for _, property := range properties[selector] {
+			key := selector + "\x00" + property
+			first, same := values[0][key]
+			for i := 1; i < len(names) && same; i++ {
+				value, ok := values[i][key]
+				same = ok && value == first
+			}
+			if same {
+				body = append(body, property+": "+first)
+				continue
+			}
+			variable := cssVariable(selector, property)
+			body = append(body, property+": var("+variable+")")
+			for i := range names {
+				value, ok := values[i][key]
+				if !ok {
+					// The theme does not set this property, so use the default value
+					value = "unset"
+				}
+				variables[i] = append(variables[i], variable+": "+value)
+			}
+		}
+		rules = append(rules, cssRule{comment: comments[selector], selector: selector, body: strings.Join(body, "; ")})
+	}
+
+	cssData := h.finishCSS(writeCSS(rules), used)
+	for i, name := range names {
+		selector := "[" + themeAttribute + "=\"" + cssString(name) + "\"]"
+		if i == 0 {
+			// The document style is used when no theme has been selected
+			selector = ":root, " + selector
+		}
+		cssData = append(cssData, selector+" { "+strings.Join(variables[i], "; ")+" }\n"...)
+	}
+	return cssData, nil
+}
+
+// cssString escapes the given string for use within double quotes in CSS
+func cssString(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\a `).Replace(s)
+}
+
+// ThemeSwitcher returns a small HTML widget for choosing between the themes
+// given with WithThemes. It sets the data-splash-theme attribute on <html>,
+// and remembers the choice in localStorage.
+func (h *Highlighter) ThemeSwitcher() []byte {
+	var buf bytes.Buffer
+	buf.WriteString(`<select class="splash-theme-switcher" aria-label="Code theme" onchange="document.documentElement.setAttribute('` + themeAttribute + `',this.value);try{localStorage.setItem('splash-theme',this.value)}catch(e){}">`)
+	for _, name := range h.themeNames() {
+		buf.WriteString(`<option value="` + html.EscapeString(name) + `">` + html.EscapeString(name) + `</option>`)
+	}
+	buf.WriteString(`</select>`)
+	buf.WriteString(`<script>(function(){var s=document.currentScript.previousElementSibling;try{var t=localStorage.getItem('splash-theme');if(t){s.value=t;if(s.value===t){document.documentElement.setAttribute('` + themeAttribute + `',t)}}}catch(e){}})()</script>`)
+	return buf.Bytes()
+}
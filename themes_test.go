@@ -0,0 +1,78 @@
+package splash
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestThemes(t *testing.T) {
+	input := []byte("<html><head></head><body><pre><code class=\"language-go\">func main() {}</code></pre></body></html>")
+	h := New(WithStyle("monokai"), WithThemes("github", "monokai"))
+
+	_, cssBytes, err := h.Highlight(input)
+	if err != nil {
+		t.Fatal(err)
+	}
+	css := string(cssBytes)
+	if !strings.Contains(css, ".chroma .k { color: var(--splash-k) }") {
+		t.Errorf("expected the token rules to use CSS custom properties: %s", css)
+	}
+	if !strings.Contains(css, `:root, [data-splash-theme="monokai"] { `) {
+		t.Errorf("expected the variables for the document style to be the default: %s", css)
+	}
+	if !strings.Contains(css, `[data-splash-theme="github"] { `) {
+		t.Errorf("expected variables for the github style: %s", css)
+	}
+	assertEqual(t, 2, strings.Count(css, "[data-splash-theme="), "expected one block of variables per style")
+
+	// Properties that are the same for all styles are kept as they are
+	if !strings.Contains(css, ".chroma .line { display: flex }") {
+		t.Errorf("expected properties that are the same for all styles to be kept: %s", css)
+	}
+
+	// Every theme sets every variable, so that the default values do not leak through
+	_, monokaiVars, _ := strings.Cut(css, `:root, [data-splash-theme="monokai"] {`)
+	monokaiVars, githubVars, _ := strings.Cut(monokaiVars, `[data-splash-theme="github"] {`)
+	assertEqual(t, strings.Count(monokaiVars, "--splash-"), strings.Count(githubVars, "--splash-"), "expected the same variables for all styles")
+	if !strings.Contains(githubVars, ": unset") {
+		t.Errorf("expected properties that github does not set to be unset: %s", githubVars)
+	}
+}
+
+func TestThemesPruned(t *testing.T) {
+	input := []byte("<pre><code class=\"language-go\">func main() {}</code></pre>")
+	_, cssBytes, err := New(WithStyle("monokai"), WithThemes("github"), WithPrunedCSS(true)).Highlight(input)
+	if err != nil {
+		t.Fatal(err)
+	}
+	css := string(cssBytes)
+	if !strings.Contains(css, "--splash-kd:") {
+		t.Errorf("expected variables for the keywords in the code: %s", css)
+	}
+	for _, unused := range []string{"--splash-s:", "--splash-c1:", "--splash-err:"} {
+		if strings.Contains(css, unused) {
+			t.Errorf("expected no %s variables for classes that are not in the code: %s", unused, css)
+		}
+	}
+}
+
+func TestThemeSwitcher(t *testing.T) {
+	h := New(WithStyle("monokai"), WithThemes("github", "dracula"))
+	widget := h.ThemeSwitcher()
+	for _, name := range []string{"monokai", "github", "dracula"} {
+		if !bytes.Contains(widget, []byte(`<option value="`+name+`">`)) {
+			t.Errorf("expected an option for %s: %s", name, widget)
+		}
+	}
+	if !bytes.Contains(widget, []byte("data-splash-theme")) {
+		t.Errorf("expected the widget to set the theme attribute: %s", widget)
+	}
+}
+
+func TestCSSVariable(t *testing.T) {
+	assertEqual(t, "--splash-k", cssVariable(".chroma .k", "color"), "")
+	assertEqual(t, "--splash-kd-font-style", cssVariable(".chroma .kd", "font-style"), "")
+	assertEqual(t, "--splash-chroma-background-color", cssVariable(".chroma", "background-color"), "")
+	assertEqual(t, "--splash-lnt-target", cssVariable(".chroma .lnt:target", "color"), "")
+}